@@ -1,7 +1,9 @@
 package account
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/diogenes-moreira/propaga-sdk/client"
@@ -22,10 +24,21 @@ func NewService(client *client.Client) *Service {
 
 // List retrieves a list of accounts based on the specified parameters
 func (s *Service) List(params *models.AccountListParams) (*models.AccountListResponse, error) {
+	return s.ListContext(context.Background(), params)
+}
+
+// ListContext is like List but honors ctx for cancellation and deadlines
+func (s *Service) ListContext(ctx context.Context, params *models.AccountListParams) (*models.AccountListResponse, error) {
 	result := &models.AccountListResponse{}
 
 	// Endpoint placeholder - should be updated when documentation is available
-	err := s.client.DoRequest(http.MethodGet, "/v1/accounts", params, result)
+	query, err := client.EncodeQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("error listing accounts: %w", err)
+	}
+
+	err = s.client.DoRequestContext(ctx, http.MethodGet, "/v1/accounts", nil, result,
+		client.WithQuery(query), client.WithOperation("accounts", "List"))
 	if err != nil {
 		return nil, fmt.Errorf("error listing accounts: %w", err)
 	}
@@ -33,13 +46,39 @@ func (s *Service) List(params *models.AccountListParams) (*models.AccountListRes
 	return result, nil
 }
 
+// ListAll returns a sequence over every account matching params, fetching pages lazily.
+// params.Offset is used as the starting point and params.Limit as page size unless opts sets one.
+func (s *Service) ListAll(ctx context.Context, params *models.AccountListParams, opts *client.IteratorOptions) iter.Seq2[models.Account, error] {
+	return s.ListIterator(ctx, params, opts).All()
+}
+
+// ListIterator returns an iterator over every account matching params, fetching pages lazily
+func (s *Service) ListIterator(ctx context.Context, params *models.AccountListParams, opts *client.IteratorOptions) *client.Iterator[models.Account] {
+	pageFields := func(p *models.AccountListParams) (*int, *int) { return &p.Limit, &p.Offset }
+	list := func(ctx context.Context, page *models.AccountListParams) ([]models.Account, int, error) {
+		result, err := s.ListContext(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data, result.TotalCount, nil
+	}
+
+	return client.ListIterator(ctx, params, pageFields, list, opts)
+}
+
 // Get retrieves a specific account by its ID
 func (s *Service) Get(id string) (*models.Account, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but honors ctx for cancellation and deadlines
+func (s *Service) GetContext(ctx context.Context, id string) (*models.Account, error) {
 	result := &models.Account{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/accounts/%s", id)
-	err := s.client.DoRequest(http.MethodGet, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodGet, path, nil, result,
+		client.WithOperation("accounts", "Get"), client.WithAttribute(client.AttributeAccountID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error getting account %s: %w", id, err)
 	}
@@ -47,12 +86,22 @@ func (s *Service) Get(id string) (*models.Account, error) {
 	return result, nil
 }
 
-// Create creates a new account
+// Create validates params and creates a new account
 func (s *Service) Create(params *models.AccountCreateParams) (*models.Account, error) {
+	return s.CreateContext(context.Background(), params)
+}
+
+// CreateContext is like Create but honors ctx for cancellation and deadlines
+func (s *Service) CreateContext(ctx context.Context, params *models.AccountCreateParams) (*models.Account, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error creating account: %w", err)
+	}
+
 	result := &models.Account{}
 
 	// Endpoint placeholder - should be updated when documentation is available
-	err := s.client.DoRequest(http.MethodPost, "/v1/accounts", params, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, "/v1/accounts", params, result,
+		client.WithOperation("accounts", "Create"))
 	if err != nil {
 		return nil, fmt.Errorf("error creating account: %w", err)
 	}
@@ -60,13 +109,23 @@ func (s *Service) Create(params *models.AccountCreateParams) (*models.Account, e
 	return result, nil
 }
 
-// Update updates an existing account
+// Update validates params and updates an existing account
 func (s *Service) Update(id string, params *models.AccountUpdateParams) (*models.Account, error) {
+	return s.UpdateContext(context.Background(), id, params)
+}
+
+// UpdateContext is like Update but honors ctx for cancellation and deadlines
+func (s *Service) UpdateContext(ctx context.Context, id string, params *models.AccountUpdateParams) (*models.Account, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error updating account %s: %w", id, err)
+	}
+
 	result := &models.Account{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/accounts/%s", id)
-	err := s.client.DoRequest(http.MethodPut, path, params, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPut, path, params, result,
+		client.WithOperation("accounts", "Update"), client.WithAttribute(client.AttributeAccountID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error updating account %s: %w", id, err)
 	}
@@ -76,11 +135,17 @@ func (s *Service) Update(id string, params *models.AccountUpdateParams) (*models
 
 // Suspend suspends an account
 func (s *Service) Suspend(id string) (*models.Account, error) {
+	return s.SuspendContext(context.Background(), id)
+}
+
+// SuspendContext is like Suspend but honors ctx for cancellation and deadlines
+func (s *Service) SuspendContext(ctx context.Context, id string) (*models.Account, error) {
 	result := &models.Account{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/accounts/%s/suspend", id)
-	err := s.client.DoRequest(http.MethodPost, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, path, nil, result,
+		client.WithOperation("accounts", "Suspend"), client.WithAttribute(client.AttributeAccountID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error suspending account %s: %w", id, err)
 	}
@@ -90,11 +155,17 @@ func (s *Service) Suspend(id string) (*models.Account, error) {
 
 // Activate activates a suspended account
 func (s *Service) Activate(id string) (*models.Account, error) {
+	return s.ActivateContext(context.Background(), id)
+}
+
+// ActivateContext is like Activate but honors ctx for cancellation and deadlines
+func (s *Service) ActivateContext(ctx context.Context, id string) (*models.Account, error) {
 	result := &models.Account{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/accounts/%s/activate", id)
-	err := s.client.DoRequest(http.MethodPost, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, path, nil, result,
+		client.WithOperation("accounts", "Activate"), client.WithAttribute(client.AttributeAccountID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error activating account %s: %w", id, err)
 	}
@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/diogenes-moreira/propaga-sdk/client"
 )
 
@@ -19,6 +22,15 @@ func NewService(client *client.Client) *Service {
 // ValidateToken validates that the API token is valid
 // Note: According to the documentation, Propaga tokens never expire
 func (s *Service) ValidateToken() (bool, error) {
+	return s.ValidateTokenContext(context.Background())
+}
+
+// ValidateTokenContext is like ValidateToken but honors ctx for cancellation and deadlines
+func (s *Service) ValidateTokenContext(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, fmt.Errorf("%w: %w", client.ErrRequestCanceled, err)
+	}
+
 	// Since tokens never expire according to the documentation,
 	// this function simply returns true if the client has a token configured
 	return s.client.APIKey != "", nil
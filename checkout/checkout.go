@@ -0,0 +1,247 @@
+// Package checkout decides whether an order can be financed by Propaga before the
+// transaction is created, combining the credit and the status of the corner store
+// with the KYC verification of its owner.
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// DefaultIVARate is the IVA (Mexican VAT) rate applied to the interests
+const DefaultIVARate = 0.16
+
+// ErrStoreMismatch is returned when the corner store found by external ID is not the one of the order
+var ErrStoreMismatch = errors.New("order for another corner store")
+
+// ReasonCode identifies why an order is not eligible
+type ReasonCode string
+
+const (
+	// ReasonStoreNotFound is reported when no corner store has the external ID
+	ReasonStoreNotFound ReasonCode = "store_not_found"
+
+	// ReasonStoreInactive is reported when the corner store is not active
+	ReasonStoreInactive ReasonCode = "store_inactive"
+
+	// ReasonInsufficientCredit is reported when the available credit does not cover the order
+	ReasonInsufficientCredit ReasonCode = "insufficient_credit"
+
+	// ReasonKYCMissing is reported when the owner of the store has no KYC verification
+	ReasonKYCMissing ReasonCode = "kyc_missing"
+
+	// ReasonKYCPending is reported when the KYC verification of the owner is still pending
+	ReasonKYCPending ReasonCode = "kyc_pending"
+
+	// ReasonKYCRejected is reported when the KYC verification of the owner was rejected or expired
+	ReasonKYCRejected ReasonCode = "kyc_rejected"
+)
+
+// Reason explains why an order is not eligible
+type Reason struct {
+	Code    ReasonCode
+	Message string
+}
+
+// Decision is the outcome of an eligibility check
+type Decision struct {
+	// Eligible reports whether the order can be financed, in which case Reasons is empty
+	Eligible bool
+
+	// Reasons lists every reason preventing the order from being financed
+	Reasons []Reason
+
+	// Store is the credit information of the corner store, nil when it was not found
+	Store *models.CornerStoreInfo
+
+	// KYC is the KYC verification of the owner of the store, nil when there is none
+	// or when the check is disabled
+	KYC *models.KYC
+
+	// Total is the amount of the order, Interests and IVA the estimated financing costs
+	// and TotalWithInterests their sum, which must be covered by the available credit
+	Total              models.Money
+	Interests          models.Money
+	IVA                models.Money
+	TotalWithInterests models.Money
+
+	// Shortfall is the amount missing from the available credit, zero when it suffices
+	Shortfall models.Money
+}
+
+// Has reports whether the decision holds a reason with the given code
+func (d *Decision) Has(code ReasonCode) bool {
+	for _, reason := range d.Reasons {
+		if reason.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary returns the reasons of the decision as a single message, empty when eligible
+func (d *Decision) Summary() string {
+	messages := make([]string, len(d.Reasons))
+	for i, reason := range d.Reasons {
+		messages[i] = reason.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Option configures a Checker
+type Option func(*Checker)
+
+// WithIVARate sets the IVA rate applied to the interests, DefaultIVARate by default
+func WithIVARate(rate float64) Option {
+	return func(c *Checker) {
+		c.ivaRate = rate
+	}
+}
+
+// WithoutKYCCheck skips the lookup of the KYC verification of the owner of the store
+func WithoutKYCCheck() Option {
+	return func(c *Checker) {
+		c.skipKYC = true
+	}
+}
+
+// Checker checks the eligibility of orders before their transactions are created
+type Checker struct {
+	cornerStores propaga.CornerStoreReader
+	kyc          propaga.KYCLister
+	interestRate float64
+	ivaRate      float64
+	skipKYC      bool
+}
+
+// New creates a Checker reading the corner stores from cornerStores and the KYC verifications
+// from kyc, such as the CornerStores and KYC services of a propaga.Client. kyc may be nil when
+// WithoutKYCCheck is used.
+//
+// The interests of an order are estimated as interestRate times its total, such as 0.05 for 5%.
+// The API only reports them once the transaction is created, so the rate has no default.
+func New(cornerStores propaga.CornerStoreReader, kyc propaga.KYCLister, interestRate float64, opts ...Option) *Checker {
+	c := &Checker{
+		cornerStores: cornerStores,
+		kyc:          kyc,
+		interestRate: interestRate,
+		ivaRate:      DefaultIVARate,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check decides whether the order described by params can be financed for the
+// corner store with the given external ID. ErrStoreMismatch is returned when
+// params.CornerStoreId is not the ID of that store.
+func (c *Checker) Check(externalID int, params *models.TransactionCreateParams) (*Decision, error) {
+	return c.CheckContext(context.Background(), externalID, params)
+}
+
+// CheckContext is like Check but honors ctx for cancellation and deadlines
+func (c *Checker) CheckContext(ctx context.Context, externalID int, params *models.TransactionCreateParams) (*Decision, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error checking eligibility: %w", err)
+	}
+
+	d := &Decision{Total: params.TotalAmount}
+	d.Interests = applyRate(d.Total, c.interestRate)
+	d.IVA = applyRate(d.Interests, c.ivaRate)
+	d.TotalWithInterests = d.Total.Add(d.Interests).Add(d.IVA)
+
+	store, err := c.cornerStores.GetCornerStoreInfoByExternalIdContext(ctx, externalID)
+	if client.IsNotFound(err) {
+		d.reject(ReasonStoreNotFound, "corner store %d not found", externalID)
+		return d, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error checking eligibility: %w", err)
+	}
+	if store.CornerStoreId != params.CornerStoreId {
+		return nil, fmt.Errorf("error checking eligibility: %w: corner store %d is %s, the order is for %s",
+			ErrStoreMismatch, externalID, store.CornerStoreId, params.CornerStoreId)
+	}
+	d.Store = store
+
+	if store.Status != models.CornerStoreStatusActive {
+		d.reject(ReasonStoreInactive, "corner store %d is %s", externalID, store.Status)
+	}
+
+	available := store.CreditLimitAvailable
+	if available.Currency() != d.Total.Currency() {
+		return nil, fmt.Errorf("error checking eligibility: order in %s but credit in %s", d.Total.Currency(), available.Currency())
+	}
+	if available.Cmp(d.TotalWithInterests) < 0 {
+		d.Shortfall = d.TotalWithInterests.Sub(available)
+		d.reject(ReasonInsufficientCredit, "available credit %s does not cover %s", available.Format(), d.TotalWithInterests.Format())
+	}
+
+	if !c.skipKYC {
+		if err := c.checkKYC(ctx, d, store.UserId); err != nil {
+			return nil, fmt.Errorf("error checking eligibility: %w", err)
+		}
+	}
+
+	d.Eligible = len(d.Reasons) == 0
+	return d, nil
+}
+
+// checkKYC adds the reasons related to the KYC verification of the user owning the store.
+// A verified verification is enough, otherwise a pending one is reported before any other.
+// Each lookup is a single request filtered by the server and limited to one result, so users
+// with many verifications do not make the check slower.
+func (c *Checker) checkKYC(ctx context.Context, d *Decision, userID string) error {
+	for _, status := range []string{models.KYCStatusVerified, models.KYCStatusPending, ""} {
+		kyc, err := c.findKYC(ctx, userID, status)
+		if err != nil {
+			return err
+		}
+		if kyc == nil {
+			continue
+		}
+
+		d.KYC = kyc
+		switch status {
+		case models.KYCStatusPending:
+			d.reject(ReasonKYCPending, "KYC verification %s of user %s is pending", kyc.ID, userID)
+		case "":
+			d.reject(ReasonKYCRejected, "KYC verification %s of user %s is %s", kyc.ID, userID, kyc.Status)
+		}
+		return nil
+	}
+
+	d.reject(ReasonKYCMissing, "user %s has no KYC verification", userID)
+	return nil
+}
+
+// findKYC returns a KYC verification of the user with the given status, any status when empty,
+// nil when there is none
+func (c *Checker) findKYC(ctx context.Context, userID, status string) (*models.KYC, error) {
+	resp, err := c.kyc.ListContext(ctx, &models.KYCListParams{CustomerID: userID, Status: status, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+	return &resp.Data[0], nil
+}
+
+// reject adds a reason to the decision
+func (d *Decision) reject(code ReasonCode, format string, args ...interface{}) {
+	d.Reasons = append(d.Reasons, Reason{Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// applyRate returns rate times m, rounded half away from zero to the centavo
+func applyRate(m models.Money, rate float64) models.Money {
+	return models.NewMoney(int64(math.Round(float64(m.Cents())*rate)), m.Currency())
+}
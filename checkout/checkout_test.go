@@ -0,0 +1,197 @@
+package checkout_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diogenes-moreira/propaga-sdk/checkout"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+	"github.com/diogenes-moreira/propaga-sdk/propagatest"
+)
+
+// order returns the parameters of an order of total centavos for the corner store cs
+func order(cs string, total int64) *models.TransactionCreateParams {
+	return &models.TransactionCreateParams{
+		CornerStoreId:           cs,
+		WholesalerTransactionId: "order-1",
+		TotalAmount:             models.MXN(total),
+		DeliveryDate:            models.NewDate(2025, time.January, 2),
+		Products:                []models.Product{{ExternalSKU: "sku-1", Name: "Product", Quantity: 1}},
+	}
+}
+
+// newServer returns a fake server with an active store (external ID 1) owned by a verified
+// user and an inactive store (external ID 2) whose owner's KYC is pending
+func newServer(t *testing.T) *propagatest.Server {
+	t.Helper()
+
+	server := propagatest.NewServer()
+	t.Cleanup(server.Close)
+
+	server.SetCornerStoreInfo(1, models.CornerStoreInfo{UserId: "user-1", CornerStoreId: "cs-1",
+		Status: models.CornerStoreStatusActive, CreditLimitAvailable: models.MXN(10000)})
+	server.SetCornerStoreInfo(2, models.CornerStoreInfo{UserId: "user-2", CornerStoreId: "cs-2",
+		Status: "inactive", CreditLimitAvailable: models.MXN(100)})
+	server.AddKYC(models.KYC{CustomerID: "user-1", Status: models.KYCStatusVerified})
+	server.AddKYC(models.KYC{CustomerID: "user-2"})
+
+	return server
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		externalID  int
+		params      *models.TransactionCreateParams
+		rate        float64
+		opts        []checkout.Option
+		wantReasons []checkout.ReasonCode
+		wantTotal   int64
+		wantMissing int64
+	}{
+		{name: "eligible", externalID: 1, params: order("cs-1", 5000), wantTotal: 5000},
+		{name: "eligible with interests", externalID: 1, params: order("cs-1", 5000), rate: 0.05, wantTotal: 5290},
+		{name: "interests exceed credit", externalID: 1, params: order("cs-1", 9800), rate: 0.05,
+			wantReasons: []checkout.ReasonCode{checkout.ReasonInsufficientCredit}, wantTotal: 10368, wantMissing: 368},
+		{name: "every reason", externalID: 2, params: order("cs-2", 5000),
+			wantReasons: []checkout.ReasonCode{checkout.ReasonStoreInactive, checkout.ReasonInsufficientCredit, checkout.ReasonKYCPending}, wantTotal: 5000, wantMissing: 4900},
+		{name: "without KYC check", externalID: 2, params: order("cs-2", 50), opts: []checkout.Option{checkout.WithoutKYCCheck()},
+			wantReasons: []checkout.ReasonCode{checkout.ReasonStoreInactive}, wantTotal: 50},
+		{name: "store not found", externalID: 3, params: order("cs-3", 5000), wantReasons: []checkout.ReasonCode{checkout.ReasonStoreNotFound}, wantTotal: 5000},
+	}
+
+	server := newServer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := server.Client()
+			d, err := checkout.New(c.CornerStores, c.KYC, tt.rate, tt.opts...).Check(tt.externalID, tt.params)
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			if d.Eligible != (len(tt.wantReasons) == 0) || len(d.Reasons) != len(tt.wantReasons) {
+				t.Fatalf("Check() = eligible %v with reasons %v, want %v", d.Eligible, d.Reasons, tt.wantReasons)
+			}
+			for _, code := range tt.wantReasons {
+				if !d.Has(code) {
+					t.Errorf("Check() reasons %v, missing %s", d.Reasons, code)
+				}
+			}
+			if d.TotalWithInterests.Cents() != tt.wantTotal || d.Shortfall.Cents() != tt.wantMissing {
+				t.Errorf("Check() total %s and shortfall %s, want %d and %d centavos", d.TotalWithInterests, d.Shortfall, tt.wantTotal, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestCheckStoreMismatch(t *testing.T) {
+	server := newServer(t)
+
+	c := server.Client()
+
+	_, err := checkout.New(c.CornerStores, c.KYC, 0).Check(2, order("cs-1", 50))
+	if !errors.Is(err, checkout.ErrStoreMismatch) {
+		t.Errorf("Check() error = %v, want ErrStoreMismatch", err)
+	}
+}
+
+func TestCheckInvalidParams(t *testing.T) {
+	server := newServer(t)
+
+	c := server.Client()
+
+	_, err := checkout.New(c.CornerStores, c.KYC, 0).Check(1, &models.TransactionCreateParams{})
+	var validationErr *models.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Check() error = %v, want a validation error", err)
+	}
+}
+
+func TestCheckKYC(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []string
+		wantReason checkout.ReasonCode
+		wantStatus string
+	}{
+		{name: "verified among rejected", statuses: []string{models.KYCStatusRejected, models.KYCStatusExpired, models.KYCStatusVerified}, wantStatus: models.KYCStatusVerified},
+		{name: "pending before rejected", statuses: []string{models.KYCStatusRejected, models.KYCStatusPending}, wantReason: checkout.ReasonKYCPending, wantStatus: models.KYCStatusPending},
+		{name: "expired", statuses: []string{models.KYCStatusExpired}, wantReason: checkout.ReasonKYCRejected, wantStatus: models.KYCStatusExpired},
+		{name: "unknown status", statuses: []string{"in_review"}, wantReason: checkout.ReasonKYCRejected, wantStatus: "in_review"},
+		{name: "missing", wantReason: checkout.ReasonKYCMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := propagatest.NewServer()
+			defer server.Close()
+			server.SetCornerStoreInfo(3, models.CornerStoreInfo{UserId: "user-3", CornerStoreId: "cs-3",
+				Status: models.CornerStoreStatusActive, CreditLimitAvailable: models.MXN(10000)})
+			server.AddKYC(models.KYC{CustomerID: "someone-else", Status: models.KYCStatusVerified})
+			for _, status := range tt.statuses {
+				server.AddKYC(models.KYC{CustomerID: "user-3", Status: status})
+			}
+			c := server.Client()
+
+			d, err := checkout.New(c.CornerStores, c.KYC, 0).Check(3, order("cs-3", 5000))
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			if tt.wantReason == "" {
+				if !d.Eligible {
+					t.Errorf("Check() reasons %v, want eligible", d.Reasons)
+				}
+			} else if len(d.Reasons) != 1 || !d.Has(tt.wantReason) {
+				t.Errorf("Check() reasons %v, want %s", d.Reasons, tt.wantReason)
+			}
+			switch {
+			case d.KYC == nil && tt.wantStatus != "":
+				t.Errorf("Check() KYC = nil, want a %q verification", tt.wantStatus)
+			case d.KYC != nil && (d.KYC.Status != tt.wantStatus || d.KYC.CustomerID != "user-3"):
+				t.Errorf("Check() KYC = %+v, want a %q verification of user-3", d.KYC, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCheckKYCBoundedLookups(t *testing.T) {
+	server := newServer(t)
+	for range 250 {
+		server.AddKYC(models.KYC{CustomerID: "user-2", Status: models.KYCStatusRejected})
+	}
+	c := server.Client()
+
+	d, err := checkout.New(c.CornerStores, c.KYC, 0).Check(2, order("cs-2", 50))
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !d.Has(checkout.ReasonKYCPending) {
+		t.Errorf("Check() reasons %v, want the pending verification reported", d.Reasons)
+	}
+
+	var lookups int
+	for _, req := range server.Requests() {
+		if req.Path != "/v1/kyc" {
+			continue
+		}
+		lookups++
+		if !strings.Contains(req.Query, "limit=1") || !strings.Contains(req.Query, "customer_id=user-2") {
+			t.Errorf("KYC lookup %s, want a single result of user-2", req.Query)
+		}
+	}
+	if lookups != 2 {
+		t.Errorf("got %d KYC lookups, want 2: verified then pending", lookups)
+	}
+}
+
+func TestCheckWithoutKYCService(t *testing.T) {
+	server := newServer(t)
+
+	d, err := checkout.New(server.Client().CornerStores, nil, 0, checkout.WithoutKYCCheck()).Check(1, order("cs-1", 50))
+	if err != nil || !d.Eligible || d.KYC != nil {
+		t.Errorf("Check() = %+v, %v, want eligible without KYC", d, err)
+	}
+}
@@ -2,11 +2,16 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
 )
 
 const (
@@ -18,8 +23,16 @@ const (
 
 	// DefaultTimeout is the default timeout for HTTP requests
 	DefaultTimeout = 30 * time.Second
+
+	// DefaultUserAgent is the User-Agent sent when none is configured
+	DefaultUserAgent = "propaga-sdk-go"
 )
 
+// ErrRequestCanceled is returned when a request is aborted because its context
+// was canceled or its deadline expired. The context error is wrapped as well, so
+// errors.Is(err, context.DeadlineExceeded) keeps working.
+var ErrRequestCanceled = errors.New("request canceled")
+
 // Client is the HTTP client for interacting with the Propaga API
 type Client struct {
 	// BaseURL is the base URL for all API requests
@@ -30,6 +43,35 @@ type Client struct {
 
 	// APIKey is the API key for authentication
 	APIKey string
+
+	// RetryPolicy controls how failed requests are retried, nil disables retries
+	RetryPolicy *RetryPolicy
+
+	// UserAgent is sent in the User-Agent header, DefaultUserAgent when empty
+	UserAgent string
+
+	// Header holds default headers added to every request
+	Header http.Header
+
+	// Logger receives a record per request attempt, nil disables logging
+	Logger *slog.Logger
+
+	// LogOptions controls the levels and the content of the records, DefaultLogOptions when nil
+	LogOptions *LogOptions
+
+	// Instrumentation observes every API call, nil disables it
+	Instrumentation Instrumentation
+
+	// RateLimiter throttles every attempt of every request, nil disables it
+	RateLimiter *RateLimiter
+
+	// Middlewares intercept every request, see Use
+	Middlewares []Middleware
+
+	// StrictDecoding makes responses holding fields unknown to the models fail with an error
+	// matching models.ErrUnknownFields instead of keeping them in their Extra, e.g. for
+	// contract tests against staging
+	StrictDecoding bool
 }
 
 // NewClient creates a new instance of the Propaga client
@@ -59,55 +101,187 @@ func NewClientWithOptions(apiKey, baseURL string, timeout time.Duration) *Client
 }
 
 // DoRequest performs an HTTP request to the Propaga API
-func (c *Client) DoRequest(method, path string, body interface{}, result interface{}) error {
+func (c *Client) DoRequest(method, path string, body interface{}, result interface{}, opts ...RequestOption) error {
+	return c.DoRequestContext(context.Background(), method, path, body, result, opts...)
+}
+
+// DoRequestContext performs an HTTP request to the Propaga API bound to ctx.
+// Cancellation and deadlines of ctx abort the request and are reported as ErrRequestCanceled.
+// The request goes through the client's Middlewares, then failed attempts are retried
+// according to the client's RetryPolicy and every attempt waits for the client's RateLimiter.
+func (c *Client) DoRequestContext(ctx context.Context, method, path string, body interface{}, result interface{}, opts ...RequestOption) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrRequestCanceled, err)
+	}
+
+	// Set headers
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set("Accept", "application/json")
+	header.Set("Authorization", c.APIKey)
+	header.Set("User-Agent", c.userAgent())
+	for key, values := range c.Header {
+		header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+
+	// Apply per-request options
+	cfg := &requestConfig{header: header}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	req := &Request{
+		Method:     method,
+		Path:       path,
+		Query:      cfg.query,
+		Header:     cfg.header,
+		Body:       body,
+		Service:    cfg.service,
+		Operation:  cfg.operation,
+		Attributes: cfg.attributes,
+	}
+	handler := c.chain(func(ctx context.Context, req *Request) (*Response, error) {
+		return c.do(ctx, req, result)
+	})
+
+	_, err := handler(ctx, req)
+	return err
+}
+
+// do performs a request once it went through the middlewares, decoding the response into result
+func (c *Client) do(ctx context.Context, req *Request, result interface{}) (resp *Response, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRequestCanceled, err)
+	}
+
+	// Prepare the request body if it exists. It is serialized once so that
+	// every attempt sends exactly the same payload.
+	var jsonBody []byte
+	if req.Body != nil {
+		jsonBody, err = json.Marshal(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing request body: %w", err)
+		}
+	}
+
 	// Build the full URL
+	method, path, header := req.Method, req.Path, req.Header
+	if header == nil {
+		header = make(http.Header)
+	}
 	url := fmt.Sprintf("%s%s", c.BaseURL, path)
+	if len(req.Query) > 0 {
+		url = fmt.Sprintf("%s?%s", url, req.Query.Encode())
+	}
 
-	// Prepare the request body if it exists
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
+	// Observe the whole call, including its retries
+	var statusCode, attempts int
+	if c.Instrumentation != nil {
+		var end func(CallResult)
+		ctx, end = c.Instrumentation.StartCall(ctx, req.info())
+		defer func() {
+			end(CallResult{StatusCode: statusCode, Attempts: attempts, Err: err})
+		}()
+		c.Instrumentation.InjectHeaders(ctx, header)
+	}
+
+	for attempt := 1; ; attempt++ {
+		attempts = attempt
+		if err := c.RateLimiter.Wait(ctx, method, path); err != nil {
+			return resp, fmt.Errorf("%w: %w", ErrRequestCanceled, err)
+		}
+
+		start := time.Now()
+		httpResp, respBody, err := c.send(ctx, method, url, header, jsonBody)
+		resp = nil
+		if httpResp != nil {
+			statusCode = httpResp.StatusCode
+			resp = &Response{
+				StatusCode: httpResp.StatusCode,
+				Header:     httpResp.Header,
+				Body:       respBody,
+				Attempts:   attempt,
+			}
+		}
+		c.RateLimiter.observe(method, path, httpResp)
+		c.logAttempt(ctx, attemptLog{
+			method:   method,
+			path:     path,
+			attempt:  attempt,
+			header:   header,
+			body:     jsonBody,
+			resp:     httpResp,
+			respBody: respBody,
+			err:      err,
+			latency:  time.Since(start),
+		})
 		if err != nil {
-			return fmt.Errorf("error serializing request body: %w", err)
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return resp, fmt.Errorf("%w: %w", ErrRequestCanceled, ctxErr)
+			}
+		} else if httpResp.StatusCode >= 400 {
+			// Check the status code
+			err = newError(method, path, header.Get(IdempotencyKeyHeader), httpResp, respBody)
+		} else {
+			// Deserialize the response if a destination was provided
+			if result != nil {
+				if err := json.Unmarshal(respBody, result); err != nil {
+					return resp, fmt.Errorf("error deserializing response: %w", err)
+				}
+				if c.StrictDecoding {
+					if err := models.CheckUnknownFields(result); err != nil {
+						return resp, fmt.Errorf("error deserializing response: %w", err)
+					}
+				}
+				resp.Result = result
+			}
+			return resp, nil
+		}
+
+		if !c.RetryPolicy.shouldRetry(attempt, method, header, httpResp) {
+			return resp, err
+		}
+
+		if err := sleep(ctx, c.RetryPolicy.delay(attempt, httpResp)); err != nil {
+			return resp, fmt.Errorf("%w: %w", ErrRequestCanceled, err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+	}
+}
+
+// send performs a single attempt of a request and returns the response along with its body
+func (c *Client) send(ctx context.Context, method, url string, header http.Header, jsonBody []byte) (*http.Response, []byte, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
 	}
 
 	// Create the HTTP request
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return fmt.Errorf("error creating HTTP request: %w", err)
+		return nil, nil, fmt.Errorf("error creating HTTP request: %w", err)
 	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", c.APIKey)
+	req.Header = header.Clone()
 
 	// Perform the request
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("error performing HTTP request: %w", err)
+		return nil, nil, fmt.Errorf("error performing HTTP request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read the response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("error reading response body: %w", err)
+		return nil, nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
-	// Check the status code
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("API error (code %d): %s", resp.StatusCode, string(respBody))
-	}
+	return resp, respBody, nil
+}
 
-	// Deserialize the response if a destination was provided
-	if result != nil {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("error deserializing response: %w", err)
-		}
+// userAgent returns the User-Agent sent with every request
+func (c *Client) userAgent() string {
+	if c.UserAgent != "" {
+		return c.UserAgent
 	}
-
-	return nil
+	return DefaultUserAgent
 }
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDoRequestContextCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := NewClientWithOptions("key", server.URL, time.Minute)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		wantErr error
+	}{
+		{
+			name:    "canceled before the request",
+			ctx:     func() (context.Context, context.CancelFunc) { return canceled, func() {} },
+			wantErr: context.Canceled,
+		},
+		{
+			name: "canceled during the request",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(20*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "deadline exceeded",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 20*time.Millisecond)
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			start := time.Now()
+			err := c.DoRequestContext(ctx, http.MethodGet, "/resource", nil, nil)
+			if !errors.Is(err, ErrRequestCanceled) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DoRequestContext() error = %v, want ErrRequestCanceled wrapping %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("DoRequestContext() returned after %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestDoRequestDecodesResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"name":"propaga"}`))
+	}))
+	defer server.Close()
+
+	var result struct{ Name string }
+	if err := NewClientWithOptions("key", server.URL, time.Second).DoRequest(http.MethodGet, "/resource", nil, &result); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if result.Name != "propaga" {
+		t.Errorf("decoded %+v", result)
+	}
+
+	err := NewClientWithOptions("other", server.URL, time.Second).DoRequest(http.MethodGet, "/resource", nil, &result)
+	if !IsUnauthorized(err) {
+		t.Errorf("DoRequest() error = %v, want unauthorized", err)
+	}
+}
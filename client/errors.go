@@ -0,0 +1,144 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// Sentinel errors matched by *Error through errors.Is
+var (
+	// ErrNotFound matches API errors with status 404
+	ErrNotFound = errors.New("resource not found")
+
+	// ErrUnauthorized matches API errors with status 401
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrConflict matches API errors with status 409
+	ErrConflict = errors.New("conflict")
+
+	// ErrRateLimited matches API errors with status 429
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrValidation matches API errors with status 400 or 422
+	ErrValidation = errors.New("validation failed")
+
+	// ErrIdempotencyConflict matches API errors with status 409 on requests sent with an
+	// idempotency key, meaning the key was already used by another request
+	ErrIdempotencyConflict = errors.New("idempotency key conflict")
+)
+
+// Error is the error returned when the Propaga API answers with a status code >= 400
+type Error struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// Method is the HTTP method of the failed request
+	Method string
+
+	// Path is the API path of the failed request
+	Path string
+
+	// Header holds the response headers
+	Header http.Header
+
+	// IdempotencyKey is the idempotency key sent with the failed request, if any
+	IdempotencyKey string
+
+	// APIError is the decoded error body, nil when the body is not a valid API error
+	APIError *models.APIError
+
+	// Body is the raw response body
+	Body []byte
+}
+
+// newError builds an *Error from a failed response
+func newError(method, path, idempotencyKey string, resp *http.Response, body []byte) *Error {
+	e := &Error{
+		StatusCode:     resp.StatusCode,
+		Method:         method,
+		Path:           path,
+		Header:         resp.Header,
+		IdempotencyKey: idempotencyKey,
+		Body:           body,
+	}
+
+	apiErr := &models.APIError{}
+	if err := json.Unmarshal(body, apiErr); err == nil && (apiErr.Code != "" || apiErr.Message != "") {
+		e.APIError = apiErr
+	}
+
+	return e
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	msg := strings.TrimSpace(string(e.Body))
+	if e.APIError != nil {
+		msg = e.APIError.Message
+		if e.APIError.Code != "" {
+			msg = fmt.Sprintf("%s: %s", e.APIError.Code, msg)
+		}
+		if e.APIError.Details != "" {
+			msg = fmt.Sprintf("%s (%s)", msg, e.APIError.Details)
+		}
+	}
+
+	return fmt.Sprintf("API error (code %d) on %s %s: %s", e.StatusCode, e.Method, e.Path, msg)
+}
+
+// Is reports whether the error matches one of the sentinel errors of this package
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrIdempotencyConflict:
+		return e.StatusCode == http.StatusConflict && e.IdempotencyKey != ""
+	}
+
+	return false
+}
+
+// IsNotFound reports whether err is an API error caused by a missing resource
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err is an API error caused by an invalid API key
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsConflict reports whether err is an API error caused by a conflicting resource state
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsRateLimited reports whether err is an API error caused by too many requests
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsValidation reports whether err is caused by invalid parameters, either rejected by the
+// API or by the client-side validation of the parameters (*models.ValidationError)
+func IsValidation(err error) bool {
+	var validationErr *models.ValidationError
+	return errors.Is(err, ErrValidation) || errors.As(err, &validationErr)
+}
+
+// IsIdempotencyConflict reports whether err is an API error caused by reusing an idempotency key
+func IsIdempotencyConflict(err error) bool {
+	return errors.Is(err, ErrIdempotencyConflict)
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+func TestErrorIs(t *testing.T) {
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrConflict, ErrRateLimited, ErrValidation, ErrIdempotencyConflict}
+
+	tests := []struct {
+		status         int
+		idempotencyKey string
+		want           []error
+	}{
+		{status: http.StatusBadRequest, want: []error{ErrValidation}},
+		{status: http.StatusUnauthorized, want: []error{ErrUnauthorized}},
+		{status: http.StatusForbidden},
+		{status: http.StatusNotFound, want: []error{ErrNotFound}},
+		{status: http.StatusConflict, want: []error{ErrConflict}},
+		{status: http.StatusConflict, idempotencyKey: "key", want: []error{ErrConflict, ErrIdempotencyConflict}},
+		{status: http.StatusUnprocessableEntity, want: []error{ErrValidation}},
+		{status: http.StatusTooManyRequests, want: []error{ErrRateLimited}},
+		{status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d %s", tt.status, tt.idempotencyKey), func(t *testing.T) {
+			// Wrapped as the services do
+			err := fmt.Errorf("error getting transaction: %w", &Error{StatusCode: tt.status, IdempotencyKey: tt.idempotencyKey})
+
+			for _, sentinel := range sentinels {
+				want := false
+				for _, w := range tt.want {
+					want = want || w == sentinel
+				}
+				if got := errors.Is(err, sentinel); got != want {
+					t.Errorf("errors.Is(%v) = %v, want %v", sentinel, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	wrap := func(status int) error {
+		return fmt.Errorf("wrapped: %w", &Error{StatusCode: status})
+	}
+
+	if !IsNotFound(wrap(http.StatusNotFound)) || IsNotFound(wrap(http.StatusBadRequest)) {
+		t.Errorf("IsNotFound mismatch")
+	}
+	if !IsUnauthorized(wrap(http.StatusUnauthorized)) || !IsConflict(wrap(http.StatusConflict)) || !IsRateLimited(wrap(http.StatusTooManyRequests)) {
+		t.Errorf("status helpers mismatch")
+	}
+	if !IsValidation(wrap(http.StatusUnprocessableEntity)) || !IsValidation(&models.ValidationError{}) || IsValidation(errors.New("other")) {
+		t.Errorf("IsValidation mismatch")
+	}
+	if IsIdempotencyConflict(wrap(http.StatusConflict)) {
+		t.Errorf("IsIdempotencyConflict matches a conflict without idempotency key")
+	}
+	if IsNotFound(nil) {
+		t.Errorf("IsNotFound(nil) = true")
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "API error",
+			body: `{"code":"not_found","message":"transaction not found","details":"id txn_1"}`,
+			want: "API error (code 404) on GET /v1/transaction/txn_1: not_found: transaction not found (id txn_1)",
+		},
+		{
+			name: "API error without code",
+			body: `{"message":"transaction not found"}`,
+			want: "API error (code 404) on GET /v1/transaction/txn_1: transaction not found",
+		},
+		{
+			name: "plain body",
+			body: "  upstream unavailable\n",
+			want: "API error (code 404) on GET /v1/transaction/txn_1: upstream unavailable",
+		},
+		{
+			name: "empty body",
+			body: "",
+			want: "API error (code 404) on GET /v1/transaction/txn_1: ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+			err := newError(http.MethodGet, "/v1/transaction/txn_1", "", resp, []byte(tt.body))
+
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewErrorDecodesAPIError(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusConflict, Header: http.Header{"X-Request-Id": {"req-1"}}}
+	err := newError(http.MethodPost, "/v1/transaction", "key-1", resp, []byte(`{"code":"duplicate","message":"already exists"}`))
+
+	if err.APIError == nil || err.APIError.Code != "duplicate" || err.IdempotencyKey != "key-1" || err.Header.Get("X-Request-Id") != "req-1" {
+		t.Errorf("newError() = %+v", err)
+	}
+
+	err = newError(http.MethodPost, "/v1/transaction", "", resp, []byte(`{"unrelated":true}`))
+	if err.APIError != nil {
+		t.Errorf("newError() decoded %+v from a body without code nor message", err.APIError)
+	}
+}
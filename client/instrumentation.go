@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Attribute keys set by the services through WithAttribute
+const (
+	AttributeTransactionID         = "propaga.transaction.id"
+	AttributeTransactionExternalID = "propaga.transaction.external_id"
+	AttributeCornerStoreID         = "propaga.corner_store.id"
+	AttributeCornerStoreExternalID = "propaga.corner_store.external_id"
+	AttributeKYCID                 = "propaga.kyc.id"
+	AttributeAccountID             = "propaga.account.id"
+)
+
+// RequestInfo describes an API call to the Instrumentation
+type RequestInfo struct {
+	// Service and Operation identify the call, such as "transactions" and "Get".
+	// They are empty for requests performed without WithOperation.
+	Service   string
+	Operation string
+
+	// Method and Path are the HTTP method and the API path of the request
+	Method string
+	Path   string
+
+	// Attributes hold the values set with WithAttribute
+	Attributes map[string]string
+}
+
+// CallResult is the outcome of an API call reported to the Instrumentation
+type CallResult struct {
+	// StatusCode is the status of the last response, zero when none was received
+	StatusCode int
+
+	// Attempts is the number of attempts performed, including retries
+	Attempts int
+
+	// Err is the error returned by the call, nil on success
+	Err error
+}
+
+// Instrumentation observes the API calls performed by a Client, e.g. to trace them.
+// See the otelpropaga package for an OpenTelemetry implementation.
+type Instrumentation interface {
+	// StartCall is called before the first attempt of a call. The returned context is used
+	// for every attempt and end is called once the call completes, after any retry.
+	StartCall(ctx context.Context, info *RequestInfo) (context.Context, func(CallResult))
+
+	// InjectHeaders adds the headers propagating ctx, such as trace context headers,
+	// to the requests of the call
+	InjectHeaders(ctx context.Context, header http.Header)
+}
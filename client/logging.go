@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RedactedValue replaces the sensitive values in log records
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedHeaders are the headers masked in log records
+var DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultRedactedFields are the JSON fields masked in the bodies of log records:
+// phone numbers, emails and the identity data of KYC verifications
+var DefaultRedactedFields = []string{
+	"phone_number", "phoneNumber",
+	"email",
+	"document_id", "documentId",
+	"date_of_birth", "dateOfBirth",
+}
+
+// requestIDHeaders are the response headers checked for the request ID
+var requestIDHeaders = []string{"X-Request-Id", "Request-Id", "X-Correlation-Id"}
+
+// LogOptions controls how requests are logged when a Logger is configured
+type LogOptions struct {
+	// Level of the records of successful attempts, slog.LevelDebug when nil
+	Level slog.Leveler
+
+	// ErrorLevel of the records of failed attempts, slog.LevelWarn when nil
+	ErrorLevel slog.Leveler
+
+	// LogHeaders adds the request headers to the records, with sensitive ones redacted
+	LogHeaders bool
+
+	// LogBodies adds the request and response bodies to the records, with sensitive fields redacted
+	LogBodies bool
+
+	// RedactedHeaders and RedactedFields are masked in addition to the default ones
+	RedactedHeaders []string
+	RedactedFields  []string
+}
+
+// DefaultLogOptions returns the options used when none are configured:
+// successful attempts at debug level, failed ones at warn level, without headers nor bodies
+func DefaultLogOptions() *LogOptions {
+	return &LogOptions{
+		Level:      slog.LevelDebug,
+		ErrorLevel: slog.LevelWarn,
+	}
+}
+
+// levelOr returns the level of leveler, fallback when it is nil
+func levelOr(leveler slog.Leveler, fallback slog.Level) slog.Level {
+	if leveler == nil {
+		return fallback
+	}
+	return leveler.Level()
+}
+
+// attemptLog holds what is logged about a single attempt of a request
+type attemptLog struct {
+	method   string
+	path     string
+	attempt  int
+	header   http.Header
+	body     []byte
+	resp     *http.Response
+	respBody []byte
+	err      error
+	latency  time.Duration
+}
+
+// logAttempt records a single attempt of a request on the configured logger
+func (c *Client) logAttempt(ctx context.Context, a attemptLog) {
+	if c.Logger == nil {
+		return
+	}
+
+	opts := c.LogOptions
+	if opts == nil {
+		opts = DefaultLogOptions()
+	}
+
+	level := levelOr(opts.Level, slog.LevelDebug)
+	if a.err != nil || (a.resp != nil && a.resp.StatusCode >= 400) {
+		level = levelOr(opts.ErrorLevel, slog.LevelWarn)
+	}
+	if !c.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", a.method),
+		slog.String("path", a.path),
+		slog.Int("attempt", a.attempt),
+		slog.Duration("latency", a.latency),
+	}
+	if a.resp != nil {
+		attrs = append(attrs, slog.Int("status", a.resp.StatusCode))
+		if requestID := requestID(a.resp.Header); requestID != "" {
+			attrs = append(attrs, slog.String("request_id", requestID))
+		}
+	}
+	if a.err != nil {
+		attrs = append(attrs, slog.String("error", a.err.Error()))
+	}
+	if opts.LogHeaders {
+		attrs = append(attrs, slog.Any("headers", RedactHeader(a.header, opts.RedactedHeaders...)))
+	}
+	if opts.LogBodies {
+		if len(a.body) > 0 {
+			attrs = append(attrs, slog.String("request_body", string(RedactBody(a.body, opts.RedactedFields...))))
+		}
+		if len(a.respBody) > 0 {
+			attrs = append(attrs, slog.String("response_body", string(RedactBody(a.respBody, opts.RedactedFields...))))
+		}
+	}
+
+	c.Logger.LogAttrs(ctx, level, "propaga request", attrs...)
+}
+
+// requestID returns the request ID sent by the API, if any
+func requestID(header http.Header) string {
+	for _, key := range requestIDHeaders {
+		if value := header.Get(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// RedactHeader returns a copy of header with the default sensitive headers,
+// and the extra ones given, replaced by RedactedValue
+func RedactHeader(header http.Header, extra ...string) http.Header {
+	redacted := header.Clone()
+	for _, key := range append(append([]string(nil), DefaultRedactedHeaders...), extra...) {
+		if _, ok := redacted[http.CanonicalHeaderKey(key)]; ok {
+			redacted.Set(key, RedactedValue)
+		}
+	}
+	return redacted
+}
+
+// RedactBody returns a copy of a JSON body with the values of the default sensitive fields,
+// and the extra ones given, replaced by RedactedValue at any depth.
+// Bodies that are not valid JSON are fully redacted.
+func RedactBody(body []byte, extra ...string) []byte {
+	fields := make(map[string]bool)
+	for _, field := range append(append([]string(nil), DefaultRedactedFields...), extra...) {
+		fields[strings.ToLower(field)] = true
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return []byte(RedactedValue)
+	}
+
+	redacted, err := json.Marshal(redactValue(v, fields))
+	if err != nil {
+		return []byte(RedactedValue)
+	}
+	return redacted
+}
+
+// redactValue walks a decoded JSON value and masks the sensitive fields
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if fields[strings.ToLower(key)] {
+				if item != nil && item != "" {
+					value[key] = RedactedValue
+				}
+				continue
+			}
+			value[key] = redactValue(item, fields)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item, fields)
+		}
+	}
+	return v
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogLevels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		opts        *LogOptions
+		wantSuccess string
+		wantFailure string
+	}{
+		{name: "default options", opts: nil, wantSuccess: "DEBUG", wantFailure: "WARN"},
+		{name: "unset levels", opts: &LogOptions{LogBodies: true}, wantSuccess: "DEBUG", wantFailure: "WARN"},
+		{name: "custom levels", opts: &LogOptions{Level: slog.LevelInfo, ErrorLevel: slog.LevelError}, wantSuccess: "INFO", wantFailure: "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			c := NewClientWithOptions("key", server.URL, time.Second)
+			c.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			c.LogOptions = tt.opts
+
+			c.DoRequest(http.MethodGet, "/ok", nil, nil)
+			c.DoRequest(http.MethodGet, "/fail", nil, nil)
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("got %d records, want 2:\n%s", len(lines), buf.String())
+			}
+			if !strings.Contains(lines[0], "level="+tt.wantSuccess) {
+				t.Errorf("success record %q, want level %s", lines[0], tt.wantSuccess)
+			}
+			if !strings.Contains(lines[1], "level="+tt.wantFailure) {
+				t.Errorf("failure record %q, want level %s", lines[1], tt.wantFailure)
+			}
+		})
+	}
+}
+
+func TestLogRedaction(t *testing.T) {
+	const responseBody = `{
+		"id": "kyc_1",
+		"email": "ana@example.com",
+		"document_id": "DOC-SECRET",
+		"customer": {"phoneNumber": "5512345678", "name": "Ana"},
+		"history": [{"dateOfBirth": "1990-05-17", "status": "verified"}, {"Email": "old@example.com"}],
+		"rfc": "RFC-SECRET",
+		"notes": null
+	}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Write([]byte(responseBody))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	c := NewClientWithOptions("API-KEY-SECRET", server.URL, time.Second)
+	c.Header = http.Header{"X-Partner-Token": {"TOKEN-SECRET"}, "X-Trace": {"trace-1"}}
+	c.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c.LogOptions = &LogOptions{
+		LogHeaders:      true,
+		LogBodies:       true,
+		RedactedHeaders: []string{"x-partner-token"},
+		RedactedFields:  []string{"rfc"},
+	}
+
+	request := map[string]interface{}{
+		"customer_id":   "user-1",
+		"phone_number":  "+525512345678",
+		"date_of_birth": "1990-05-17",
+		"email":         "",
+		"documents":     []interface{}{map[string]interface{}{"document_id": "DOC-SECRET", "type": "passport"}},
+		"extra":         map[string]interface{}{"rfc": "RFC-SECRET", "plan": "gold"},
+	}
+	if err := c.DoRequest(http.MethodPost, "/v1/kyc", request, nil); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+
+	for _, secret := range []string{"API-KEY-SECRET", "TOKEN-SECRET", "DOC-SECRET", "RFC-SECRET", "5512345678", "1990-05-17", "example.com"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("log record leaks %q:\n%s", secret, buf.String())
+		}
+	}
+
+	var record struct {
+		Headers      map[string][]string `json:"headers"`
+		RequestBody  string              `json:"request_body"`
+		ResponseBody string              `json:"response_body"`
+		RequestID    string              `json:"request_id"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("invalid log record %q: %v", buf.String(), err)
+	}
+
+	if got := record.Headers["Authorization"]; len(got) != 1 || got[0] != RedactedValue {
+		t.Errorf("Authorization header logged as %v", got)
+	}
+	if got := record.Headers["X-Partner-Token"]; len(got) != 1 || got[0] != RedactedValue {
+		t.Errorf("X-Partner-Token header logged as %v", got)
+	}
+	if got := record.Headers["X-Trace"]; len(got) != 1 || got[0] != "trace-1" {
+		t.Errorf("X-Trace header logged as %v, want it kept", got)
+	}
+	if record.RequestID != "req-1" {
+		t.Errorf("request_id = %q, want req-1", record.RequestID)
+	}
+
+	wantRequest := `{"customer_id":"user-1","date_of_birth":"[REDACTED]","documents":[{"document_id":"[REDACTED]","type":"passport"}],` +
+		`"email":"","extra":{"plan":"gold","rfc":"[REDACTED]"},"phone_number":"[REDACTED]"}`
+	if record.RequestBody != wantRequest {
+		t.Errorf("request_body = %s, want %s", record.RequestBody, wantRequest)
+	}
+
+	wantResponse := `{"customer":{"name":"Ana","phoneNumber":"[REDACTED]"},"document_id":"[REDACTED]","email":"[REDACTED]",` +
+		`"history":[{"dateOfBirth":"[REDACTED]","status":"verified"},{"Email":"[REDACTED]"}],"id":"kyc_1","notes":null,"rfc":"[REDACTED]"}`
+	if record.ResponseBody != wantResponse {
+		t.Errorf("response_body = %s, want %s", record.ResponseBody, wantResponse)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		extra []string
+		want  string
+	}{
+		{name: "top level", body: `{"email":"a@b.c","id":1}`, want: `{"email":"[REDACTED]","id":1}`},
+		{name: "case insensitive", body: `{"EMAIL":"a@b.c","PhoneNumber":"1"}`, want: `{"EMAIL":"[REDACTED]","PhoneNumber":"[REDACTED]"}`},
+		{name: "nested arrays", body: `[[{"document_id":"x"}]]`, want: `[[{"document_id":"[REDACTED]"}]]`},
+		{name: "object value", body: `{"email":{"primary":"a@b.c"}}`, want: `{"email":"[REDACTED]"}`},
+		{name: "empty values kept", body: `{"email":"","phone_number":null}`, want: `{"email":"","phone_number":null}`},
+		{name: "extra fields", body: `{"rfc":"x","curp":"y"}`, extra: []string{"RFC"}, want: `{"curp":"y","rfc":"[REDACTED]"}`},
+		{name: "large numbers kept", body: `{"amount":12345678901234567890}`, want: `{"amount":12345678901234567890}`},
+		{name: "not JSON", body: `email=a@b.c`, want: RedactedValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(RedactBody([]byte(tt.body), tt.extra...)); got != tt.want {
+				t.Errorf("RedactBody() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	header := http.Header{"Authorization": {"key"}, "Cookie": {"a=b"}, "X-Secret": {"s"}, "Accept": {"application/json"}}
+
+	got := RedactHeader(header, "x-secret")
+
+	want := http.Header{"Authorization": {RedactedValue}, "Cookie": {RedactedValue}, "X-Secret": {RedactedValue}, "Accept": {"application/json"}}
+	for key := range want {
+		if got.Get(key) != want.Get(key) {
+			t.Errorf("header %s = %q, want %q", key, got.Get(key), want.Get(key))
+		}
+	}
+	if header.Get("Authorization") != "key" {
+		t.Errorf("RedactHeader() modified its argument")
+	}
+}
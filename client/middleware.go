@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Request is an API request as seen by the middlewares.
+// Middlewares may modify it before passing it to the next Handler.
+type Request struct {
+	// Method and Path are the HTTP method and the API path of the request
+	Method string
+	Path   string
+
+	// Query holds the query parameters appended to the URL
+	Query url.Values
+
+	// Header holds the headers sent with every attempt of the request
+	Header http.Header
+
+	// Body is the value serialized as the JSON body, nil when the request has none
+	Body any
+
+	// Service, Operation and Attributes describe the call, see WithOperation and WithAttribute
+	Service    string
+	Operation  string
+	Attributes map[string]string
+}
+
+// info describes the request for the Instrumentation
+func (r *Request) info() *RequestInfo {
+	return &RequestInfo{
+		Service:    r.Service,
+		Operation:  r.Operation,
+		Method:     r.Method,
+		Path:       r.Path,
+		Attributes: r.Attributes,
+	}
+}
+
+// Response is the response to an API request as seen by the middlewares
+type Response struct {
+	// StatusCode and Header are those of the last response received
+	StatusCode int
+	Header     http.Header
+
+	// Body is the raw body of the response
+	Body []byte
+
+	// Result is the destination the body was decoded into, nil when the caller expects no result
+	// or when the request failed
+	Result any
+
+	// Attempts is the number of attempts performed, including retries
+	Attempts int
+}
+
+// Handler performs an API request. The Response is returned along with the error
+// when the API replied with an error status, and is nil when no response was received.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler to intercept every request performed by a Client,
+// e.g. to add headers or to audit requests and their results
+type Middleware func(next Handler) Handler
+
+// Use appends middlewares to the client. The first middleware registered is the
+// outermost one, seeing requests first and responses last.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.Middlewares = append(c.Middlewares, middlewares...)
+}
+
+// chain wraps handler with the middlewares of the client
+func (c *Client) chain(handler Handler) Handler {
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		handler = c.Middlewares[i](handler)
+	}
+	return handler
+}
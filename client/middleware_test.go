@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tracing returns a middleware appending its name to trace before and after the next handler
+func tracing(name string, trace *[]string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			*trace = append(*trace, name+" in")
+			resp, err := next(ctx, req)
+			*trace = append(*trace, name+" out")
+			return resp, err
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var trace []string
+	c := NewClientWithOptions("key", server.URL, time.Second)
+	c.Middlewares = []Middleware{tracing("a", &trace)}
+	c.Use(tracing("b", &trace), tracing("c", &trace))
+
+	if err := c.DoRequest(http.MethodGet, "/resource", nil, nil); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+
+	want := []string{"a in", "b in", "c in", "c out", "b out", "a out"}
+	if !slices.Equal(trace, want) {
+		t.Errorf("trace = %v, want %v", trace, want)
+	}
+}
+
+func TestMiddlewareMutatesRequest(t *testing.T) {
+	var got *http.Request
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClientWithOptions("key", server.URL, time.Second)
+	c.Use(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("X-Tenant", "tenant-1")
+			req.Header.Del("User-Agent")
+			req.Path = "/v2" + req.Path
+			if req.Query == nil {
+				req.Query = url.Values{}
+			}
+			req.Query.Set("source", "middleware")
+			req.Body = map[string]string{"replaced": "true"}
+			return next(ctx, req)
+		}
+	})
+
+	if err := c.DoRequest(http.MethodPost, "/resource", map[string]string{"original": "true"}, nil); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+
+	if got.URL.Path != "/v2/resource" || got.URL.Query().Get("source") != "middleware" {
+		t.Errorf("server received %s, want /v2/resource?source=middleware", got.URL)
+	}
+	if got.Header.Get("X-Tenant") != "tenant-1" || got.Header.Get("Authorization") != "key" {
+		t.Errorf("server received headers %v", got.Header)
+	}
+	if ua := got.Header.Get("User-Agent"); ua == DefaultUserAgent {
+		t.Errorf("User-Agent = %q, want the default one removed", ua)
+	}
+	if string(gotBody) != `{"replaced":"true"}` {
+		t.Errorf("server received body %s", gotBody)
+	}
+}
+
+func TestMiddlewareShortCircuits(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"name":"from server"}`))
+	}))
+	defer server.Close()
+
+	errDenied := errors.New("denied")
+	c := NewClientWithOptions("key", server.URL, time.Second)
+	c.Use(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			switch req.Path {
+			case "/denied":
+				return nil, errDenied
+			case "/cached":
+				return &Response{StatusCode: http.StatusOK}, nil
+			}
+			return next(ctx, req)
+		}
+	})
+
+	var result struct{ Name string }
+	if err := c.DoRequest(http.MethodGet, "/denied", nil, &result); !errors.Is(err, errDenied) {
+		t.Errorf("DoRequest(/denied) error = %v, want the middleware error", err)
+	}
+	if err := c.DoRequest(http.MethodGet, "/cached", nil, &result); err != nil || result.Name != "" {
+		t.Errorf("DoRequest(/cached) = %+v, %v, want an untouched result", result, err)
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("short-circuited requests reached the server %d times", calls.Load())
+	}
+
+	if err := c.DoRequest(http.MethodGet, "/other", nil, &result); err != nil || result.Name != "from server" {
+		t.Errorf("DoRequest(/other) = %+v, %v", result, err)
+	}
+}
+
+func TestMiddlewareSeesResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Write([]byte(`{"name":"store"}`))
+	}))
+	defer server.Close()
+
+	var responses []*Response
+	var errs []error
+	c := NewClientWithOptions("key", server.URL, time.Second)
+	c.Use(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := next(ctx, req)
+			responses = append(responses, resp)
+			errs = append(errs, err)
+			return resp, err
+		}
+	})
+
+	var result struct{ Name string }
+	c.DoRequest(http.MethodGet, "/found", nil, &result)
+	c.DoRequest(http.MethodGet, "/missing", nil, nil)
+
+	ok := responses[0]
+	if errs[0] != nil || ok.StatusCode != http.StatusOK || ok.Header.Get("X-Request-Id") != "req-1" || ok.Result != &result || ok.Attempts != 1 {
+		t.Errorf("successful response = %+v, %v", ok, errs[0])
+	}
+	if missing := responses[1]; missing == nil || missing.StatusCode != http.StatusNotFound || !IsNotFound(errs[1]) {
+		t.Errorf("failed response = %+v, %v, want the 404 response along with the error", missing, errs[1])
+	}
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// RequestOption customizes a single request performed by DoRequestContext
+type RequestOption func(*requestConfig)
+
+// requestConfig holds the per-request settings built from the RequestOptions
+type requestConfig struct {
+	header     http.Header
+	query      url.Values
+	service    string
+	operation  string
+	attributes map[string]string
+}
+
+// WithHeader sets a header on the request, replacing any existing value
+func WithHeader(key, value string) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.header.Set(key, value)
+	}
+}
+
+// WithIdempotencyKey sends key in the Idempotency-Key header.
+// The same key is reused by every retry of the request.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(cfg *requestConfig) {
+		if key != "" {
+			cfg.header.Set(IdempotencyKeyHeader, key)
+		}
+	}
+}
+
+// WithQuery appends query parameters to the request URL
+func WithQuery(query url.Values) RequestOption {
+	return func(cfg *requestConfig) {
+		if cfg.query == nil {
+			cfg.query = url.Values{}
+		}
+		for key, values := range query {
+			cfg.query[key] = append(cfg.query[key], values...)
+		}
+	}
+}
+
+// WithOperation names the service and the operation performing the request, such as
+// "transactions" and "Get". They identify the call for the Instrumentation.
+func WithOperation(service, operation string) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.service = service
+		cfg.operation = operation
+	}
+}
+
+// WithAttribute attaches an attribute describing the request, such as the ID of the
+// transaction it targets, for the Instrumentation
+func WithAttribute(key, value string) RequestOption {
+	return func(cfg *requestConfig) {
+		if cfg.attributes == nil {
+			cfg.attributes = make(map[string]string)
+		}
+		cfg.attributes[key] = value
+	}
+}
+
+// NewIdempotencyKey generates a random idempotency key in UUID v4 format
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// DefaultPageSize is the number of items requested per page when none is configured
+const DefaultPageSize = 50
+
+// PageFunc fetches the page of items starting at offset.
+// It returns the items of the page and the total number of items reported by the API.
+type PageFunc[T any] func(ctx context.Context, limit, offset int) (items []T, totalCount int, err error)
+
+// IteratorOptions configures how an Iterator walks through the pages of a List endpoint
+type IteratorOptions struct {
+	// PageSize is the number of items requested per page, DefaultPageSize when zero
+	PageSize int
+
+	// MaxItems caps the number of items returned by the iterator, zero means no limit
+	MaxItems int
+}
+
+// Iterator walks through every item of a paginated List endpoint, fetching pages lazily.
+//
+//	it := client.Transactions.ListIterator(ctx, params, nil)
+//	for it.Next() {
+//		tx := it.Current()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+	opts  IteratorOptions
+
+	page     []T
+	pos      int
+	offset   int
+	returned int
+	current  T
+	lastPage bool
+	err      error
+}
+
+// NewIterator creates an iterator over the pages returned by fetch
+func NewIterator[T any](ctx context.Context, fetch PageFunc[T], opts *IteratorOptions) *Iterator[T] {
+	it := &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.PageSize <= 0 {
+		it.opts.PageSize = DefaultPageSize
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next item, fetching a new page when needed.
+// It returns false when there are no more items or an error occurred, see Err.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.opts.MaxItems > 0 && it.returned >= it.opts.MaxItems {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = fmt.Errorf("%w: %w", ErrRequestCanceled, err)
+		return false
+	}
+
+	if it.pos >= len(it.page) {
+		if it.lastPage {
+			return false
+		}
+		if !it.fetchPage() {
+			return false
+		}
+	}
+
+	it.current = it.page[it.pos]
+	it.pos++
+	it.returned++
+
+	return true
+}
+
+// fetchPage loads the next page and reports whether it holds any item
+func (it *Iterator[T]) fetchPage() bool {
+	limit := it.opts.PageSize
+	if it.opts.MaxItems > 0 {
+		limit = min(limit, it.opts.MaxItems-it.returned)
+	}
+
+	items, total, err := it.fetch(it.ctx, limit, it.offset)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page = items
+	it.pos = 0
+	it.offset += len(items)
+	if total > 0 {
+		// The server may cap the page size below limit, so only the total ends the walk
+		it.lastPage = it.offset >= total
+	} else {
+		it.lastPage = len(items) < limit
+	}
+
+	return len(items) > 0
+}
+
+// Current returns the item the iterator is positioned on
+func (it *Iterator[T]) Current() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All returns the remaining items as a range-over-func sequence.
+// An error stops the sequence and is yielded along with the zero value of T.
+func (it *Iterator[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.Current(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// ListFunc fetches the page of a List endpoint selected by params.
+// It returns the items of the page and the total number of items reported by the API.
+type ListFunc[P, T any] func(ctx context.Context, params *P) (items []T, totalCount int, err error)
+
+// PageFields returns the limit and offset fields of the List parameters p
+type PageFields[P any] func(p *P) (limit, offset *int)
+
+// ListIterator returns an iterator over every item of a List endpoint, fetching pages lazily
+// through list. The offset of params is used as the starting point and its limit as page size
+// unless opts sets one, both being located in params by fields.
+func ListIterator[P, T any](ctx context.Context, params *P, fields PageFields[P], list ListFunc[P, T], opts *IteratorOptions) *Iterator[T] {
+	var base P
+	if params != nil {
+		base = *params
+	}
+	baseLimit, baseOffset := fields(&base)
+
+	iterOpts := IteratorOptions{PageSize: *baseLimit}
+	if opts != nil {
+		iterOpts.MaxItems = opts.MaxItems
+		if opts.PageSize > 0 {
+			iterOpts.PageSize = opts.PageSize
+		}
+	}
+
+	start := *baseOffset
+	fetch := func(ctx context.Context, limit, offset int) ([]T, int, error) {
+		page := base
+		pageLimit, pageOffset := fields(&page)
+		*pageLimit = limit
+		*pageOffset = start + offset
+
+		items, total, err := list(ctx, &page)
+		if total > 0 {
+			// The total counts the items skipped by the starting offset as well
+			total = max(total-start, 0)
+		}
+		return items, total, err
+	}
+
+	return NewIterator(ctx, fetch, &iterOpts)
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// pages returns a PageFunc serving n items, capping each page at maxPage items.
+// The total is reported only when withTotal is set.
+func pages(n, maxPage int, withTotal bool) (PageFunc[int], *int) {
+	calls := 0
+	return func(ctx context.Context, limit, offset int) ([]int, int, error) {
+		calls++
+		var items []int
+		for i := offset; i < n && len(items) < min(limit, maxPage); i++ {
+			items = append(items, i)
+		}
+		total := 0
+		if withTotal {
+			total = n
+		}
+		return items, total, nil
+	}, &calls
+}
+
+func TestIterator(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         int
+		maxPage   int
+		withTotal bool
+		opts      *IteratorOptions
+		want      int
+		wantCalls int
+	}{
+		{name: "single page", n: 10, maxPage: 100, withTotal: true, opts: &IteratorOptions{PageSize: 20}, want: 10, wantCalls: 1},
+		{name: "exact pages with total", n: 40, maxPage: 100, withTotal: true, opts: &IteratorOptions{PageSize: 20}, want: 40, wantCalls: 2},
+		{name: "server caps pages with total", n: 100, maxPage: 20, withTotal: true, opts: &IteratorOptions{PageSize: 50}, want: 100, wantCalls: 5},
+		{name: "short page without total", n: 30, maxPage: 100, withTotal: false, opts: &IteratorOptions{PageSize: 20}, want: 30, wantCalls: 2},
+		{name: "exact pages without total", n: 40, maxPage: 100, withTotal: false, opts: &IteratorOptions{PageSize: 20}, want: 40, wantCalls: 3},
+		{name: "max items", n: 100, maxPage: 100, withTotal: true, opts: &IteratorOptions{PageSize: 20, MaxItems: 25}, want: 25, wantCalls: 2},
+		{name: "empty", n: 0, maxPage: 100, withTotal: true, want: 0, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetch, calls := pages(tt.n, tt.maxPage, tt.withTotal)
+			it := NewIterator(context.Background(), fetch, tt.opts)
+
+			var got []int
+			for it.Next() {
+				got = append(got, it.Current())
+			}
+			if err := it.Err(); err != nil {
+				t.Fatalf("Err() = %v", err)
+			}
+			if len(got) != tt.want || *calls != tt.wantCalls {
+				t.Errorf("got %d items in %d calls, want %d items in %d calls", len(got), *calls, tt.want, tt.wantCalls)
+			}
+			for i, v := range got {
+				if v != i {
+					t.Fatalf("item %d = %d, want %d", i, v, i)
+				}
+			}
+		})
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	fetch := func(ctx context.Context, limit, offset int) ([]int, int, error) {
+		if offset > 0 {
+			return nil, 0, errFetch
+		}
+		return []int{0, 1}, 10, nil
+	}
+
+	var got []int
+	var err error
+	for v, e := range NewIterator(context.Background(), fetch, &IteratorOptions{PageSize: 2}).All() {
+		if e != nil {
+			err = e
+			break
+		}
+		got = append(got, v)
+	}
+	if len(got) != 2 || !errors.Is(err, errFetch) {
+		t.Errorf("got %v, %v, want 2 items and the fetch error", got, err)
+	}
+}
+
+func TestIteratorCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fetch, calls := pages(10, 10, true)
+	it := NewIterator(ctx, fetch, nil)
+	if it.Next() || !errors.Is(it.Err(), ErrRequestCanceled) || *calls != 0 {
+		t.Errorf("got err %v after %d calls, want ErrRequestCanceled without calls", it.Err(), *calls)
+	}
+}
+
+func TestListIterator(t *testing.T) {
+	type params struct {
+		Filter        string
+		Limit, Offset int
+	}
+	fields := func(p *params) (*int, *int) { return &p.Limit, &p.Offset }
+
+	var seen []params
+	list := func(ctx context.Context, p *params) ([]int, int, error) {
+		seen = append(seen, *p)
+		var items []int
+		for i := p.Offset; i < 25 && len(items) < p.Limit; i++ {
+			items = append(items, i)
+		}
+		return items, 25, nil
+	}
+
+	in := &params{Filter: "f", Limit: 10, Offset: 5}
+	var got []int
+	for v, err := range ListIterator(context.Background(), in, fields, list, nil).All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, v)
+	}
+
+	if len(got) != 20 || got[0] != 5 || got[19] != 24 {
+		t.Errorf("got %v, want items 5 to 24", got)
+	}
+	want := []params{{"f", 10, 5}, {"f", 10, 15}}
+	if len(seen) != len(want) || seen[0] != want[0] || seen[1] != want[1] {
+		t.Errorf("got pages %v, want %v", seen, want)
+	}
+	if in.Limit != 10 || in.Offset != 5 {
+		t.Errorf("params modified to %+v", *in)
+	}
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts used to encode time.Time values in query parameters
+const (
+	// QueryDateLayout is used for fields tagged with the "date" option
+	QueryDateLayout = "2006-01-02"
+
+	// QueryTimeLayout is used for every other time.Time field
+	QueryTimeLayout = time.RFC3339
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// EncodeQuery encodes the exported fields of a struct, or pointer to struct, as URL query parameters.
+//
+// Field names are taken from the "url" struct tag, falling back to the "json" tag and then to
+// the field name. The tag supports the following options:
+//
+//	url:"name"            always sent, zero values included
+//	url:"name,omitempty"  skipped when the field holds its zero value
+//	url:"name,date"       time.Time encoded as YYYY-MM-DD instead of RFC 3339
+//	url:"-"               never sent
+//
+// Strings (including string based enums), integers, floats, booleans, time.Time and
+// encoding.TextMarshaler values are supported. Slices are encoded as repeated parameters
+// and nil pointers are skipped. A nil v yields empty values.
+func EncodeQuery(v interface{}) (url.Values, error) {
+	values := url.Values{}
+	if v == nil {
+		return values, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return values, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query parameters must be a struct, got %s", rv.Type())
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts := queryFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if opts.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+			for j := 0; j < fv.Len(); j++ {
+				s, ok, err := encodeQueryValue(fv.Index(j), opts)
+				if err != nil {
+					return nil, fmt.Errorf("error encoding query parameter %s: %w", name, err)
+				}
+				if ok {
+					values.Add(name, s)
+				}
+			}
+			continue
+		}
+
+		s, ok, err := encodeQueryValue(fv, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding query parameter %s: %w", name, err)
+		}
+		if ok {
+			values.Add(name, s)
+		}
+	}
+
+	return values, nil
+}
+
+// queryOptions are the options parsed from a struct tag
+type queryOptions struct {
+	omitEmpty bool
+	date      bool
+}
+
+// queryFieldName returns the parameter name and the options of a struct field
+func queryFieldName(field reflect.StructField) (string, queryOptions) {
+	tag, ok := field.Tag.Lookup("url")
+	if !ok {
+		tag, ok = field.Tag.Lookup("json")
+	}
+
+	var opts queryOptions
+	if !ok {
+		return field.Name, opts
+	}
+
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "omitempty":
+			opts.omitEmpty = true
+		case "date":
+			opts.date = true
+		}
+	}
+
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, opts
+}
+
+// encodeQueryValue converts a single value to its query representation.
+// It returns false when the value must not be sent.
+func encodeQueryValue(v reflect.Value, opts queryOptions) (string, bool, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false, nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if opts.date {
+			return t.Format(QueryDateLayout), true, nil
+		}
+		return t.Format(QueryTimeLayout), true, nil
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", false, err
+		}
+		return string(text), true, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), true, nil
+	}
+
+	return "", false, fmt.Errorf("unsupported type %s", v.Type())
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type queryStatus string
+
+// upperText encodes as text through encoding.TextMarshaler
+type upperText struct{ value string }
+
+func (u upperText) MarshalText() ([]byte, error) {
+	if u.value == "" {
+		return nil, errors.New("empty value")
+	}
+	return []byte("text:" + u.value), nil
+}
+
+func TestEncodeQuery(t *testing.T) {
+	day := time.Date(2025, time.March, 4, 10, 30, 0, 0, time.UTC)
+	limit := 20
+
+	tests := []struct {
+		name    string
+		params  interface{}
+		want    url.Values
+		wantErr bool
+	}{
+		{name: "nil", params: nil, want: url.Values{}},
+		{name: "nil pointer", params: (*struct{ A string })(nil), want: url.Values{}},
+		{
+			name: "names from url and json tags",
+			params: struct {
+				Status queryStatus `url:"status"`
+				Page   int         `json:"page"`
+				Plain  bool
+			}{Status: "pending", Page: 2, Plain: true},
+			want: url.Values{"status": {"pending"}, "page": {"2"}, "Plain": {"true"}},
+		},
+		{
+			name: "url tag takes precedence over json",
+			params: struct {
+				Status string `url:"estado" json:"status"`
+			}{Status: "paid"},
+			want: url.Values{"estado": {"paid"}},
+		},
+		{
+			name: "zero values sent without omitempty",
+			params: struct {
+				Offset int    `url:"offset"`
+				Query  string `url:"q"`
+			}{},
+			want: url.Values{"offset": {"0"}, "q": {""}},
+		},
+		{
+			name: "omitempty",
+			params: struct {
+				Offset int       `url:"offset,omitempty"`
+				Query  string    `url:"q,omitempty"`
+				From   time.Time `url:"from,omitempty"`
+				IDs    []string  `url:"id,omitempty"`
+				Limit  *int      `url:"limit,omitempty"`
+			}{},
+			want: url.Values{},
+		},
+		{
+			name: "date option",
+			params: struct {
+				From time.Time `url:"from,date"`
+				To   time.Time `url:"to"`
+			}{From: day, To: day},
+			want: url.Values{"from": {"2025-03-04"}, "to": {"2025-03-04T10:30:00Z"}},
+		},
+		{
+			name: "text marshaler",
+			params: struct {
+				Value upperText `url:"value"`
+			}{Value: upperText{value: "a"}},
+			want: url.Values{"value": {"text:a"}},
+		},
+		{
+			name: "slices",
+			params: struct {
+				IDs      []string      `url:"id"`
+				Statuses []queryStatus `url:"status"`
+				Empty    []int         `url:"empty"`
+			}{IDs: []string{"a", "b"}, Statuses: []queryStatus{"paid"}},
+			want: url.Values{"id": {"a", "b"}, "status": {"paid"}},
+		},
+		{
+			name: "pointers",
+			params: &struct {
+				Limit *int `url:"limit"`
+				Page  *int `url:"page"`
+			}{Limit: &limit},
+			want: url.Values{"limit": {"20"}},
+		},
+		{
+			name: "numbers",
+			params: struct {
+				Count uint8   `url:"count"`
+				Ratio float64 `url:"ratio"`
+				Small float32 `url:"small"`
+			}{Count: 3, Ratio: 0.25, Small: 1.5},
+			want: url.Values{"count": {"3"}, "ratio": {"0.25"}, "small": {"1.5"}},
+		},
+		{
+			name: "unexported and skipped fields",
+			params: struct {
+				hidden string
+				Secret string `url:"-"`
+				Other  string `json:"-"`
+				Shown  string `url:"shown"`
+			}{hidden: "x", Secret: "y", Other: "z", Shown: "w"},
+			want: url.Values{"shown": {"w"}},
+		},
+		{name: "not a struct", params: map[string]string{"a": "b"}, wantErr: true},
+		{name: "pointer to a non struct", params: new(int), wantErr: true},
+		{
+			name: "unsupported field type",
+			params: struct {
+				Filter map[string]string `url:"filter"`
+			}{Filter: map[string]string{}},
+			wantErr: true,
+		},
+		{
+			name: "text marshaler error",
+			params: struct {
+				Value upperText `url:"value"`
+			}{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeQuery(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EncodeQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EncodeQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers sent by the server to report the state of its rate limit
+const (
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimit configures a RateLimiter
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests, zero or negative means unlimited
+	RequestsPerSecond float64
+
+	// Burst is the number of requests that can be sent at once, at least 1
+	Burst int
+
+	// Endpoints overrides the limit of the requests matching their path.
+	// Requests matching an endpoint only consume its tokens, not the default ones.
+	Endpoints []EndpointLimit
+
+	// IgnoreServerHeaders disables the adaptation to the X-RateLimit-Remaining
+	// and X-RateLimit-Reset headers sent by the server
+	IgnoreServerHeaders bool
+}
+
+// EndpointLimit is the limit of the requests whose path matches Path.
+// Path segments written as {name} match any segment and a trailing * matches any suffix,
+// e.g. "/v1/corner-store/external/{id}" or "/v1/transaction/*".
+type EndpointLimit struct {
+	// Method restricts the limit to a HTTP method, any method when empty
+	Method string
+
+	// Path is the pattern matched against the path of the request
+	Path string
+
+	// RequestsPerSecond is the sustained rate of requests, zero or negative means unlimited
+	RequestsPerSecond float64
+
+	// Burst is the number of requests that can be sent at once, at least 1
+	Burst int
+}
+
+// RateLimiter is a token-bucket limiter shared by every request of a Client.
+// A nil RateLimiter does not limit requests.
+type RateLimiter struct {
+	base      *bucket
+	endpoints []endpointBucket
+	adaptive  bool
+	now       func() time.Time
+}
+
+// endpointBucket associates an endpoint override with its bucket
+type endpointBucket struct {
+	limit  EndpointLimit
+	bucket *bucket
+}
+
+// NewRateLimiter creates a rate limiter from limit
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		base:     newBucket(limit.RequestsPerSecond, limit.Burst),
+		adaptive: !limit.IgnoreServerHeaders,
+		now:      time.Now,
+	}
+	for _, endpoint := range limit.Endpoints {
+		l.endpoints = append(l.endpoints, endpointBucket{
+			limit:  endpoint,
+			bucket: newBucket(endpoint.RequestsPerSecond, endpoint.Burst),
+		})
+	}
+	return l
+}
+
+// Wait blocks until a request with the given method and path is allowed, or until ctx is done.
+// Tokens are only taken when the request is allowed, so a Wait that gives up on ctx does not
+// delay the others. Waiting requests are not served in arrival order.
+func (l *RateLimiter) Wait(ctx context.Context, method, path string) error {
+	if l == nil {
+		return nil
+	}
+
+	b := l.bucketFor(method, path)
+	for {
+		d := b.take(l.now())
+		if d <= 0 {
+			return nil
+		}
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// observe adapts the limiter to the rate limit headers of a response
+func (l *RateLimiter) observe(method, path string, resp *http.Response) {
+	if l == nil || !l.adaptive || resp == nil {
+		return
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get(RateLimitRemainingHeader))
+	if err != nil || remaining < 0 {
+		return
+	}
+	reset, ok := parseRateLimitReset(resp.Header.Get(RateLimitResetHeader), l.now())
+	if !ok {
+		return
+	}
+
+	l.bucketFor(method, path).adapt(remaining, reset, l.now())
+}
+
+// bucketFor returns the bucket consumed by a request, the first matching endpoint or the default one
+func (l *RateLimiter) bucketFor(method, path string) *bucket {
+	for _, endpoint := range l.endpoints {
+		if endpoint.limit.matches(method, path) {
+			return endpoint.bucket
+		}
+	}
+	return l.base
+}
+
+// matches reports whether the endpoint applies to a request
+func (e EndpointLimit) matches(method, path string) bool {
+	if e.Method != "" && !strings.EqualFold(e.Method, method) {
+		return false
+	}
+
+	pattern := strings.Split(strings.Trim(e.Path, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range pattern {
+		if part == "*" && i == len(pattern)-1 {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			continue
+		}
+		if part != segments[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(segments)
+}
+
+// bucket is a token bucket
+type bucket struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+	blocked time.Time
+}
+
+// newBucket creates a full bucket
+func newBucket(rate float64, burst int) *bucket {
+	return &bucket{
+		rate:   rate,
+		burst:  float64(max(burst, 1)),
+		tokens: float64(max(burst, 1)),
+	}
+}
+
+// take takes a token when one is available and returns zero, otherwise it returns how
+// long the caller must wait before trying again
+func (b *bucket) take(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if blocked := b.blocked.Sub(now); blocked > 0 {
+		return blocked
+	}
+	if b.rate <= 0 {
+		return 0
+	}
+
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) / b.rate * float64(time.Second)))
+}
+
+// adapt aligns the bucket with the remaining requests reported by the server.
+// When none remain, requests are held until reset.
+func (b *bucket) adapt(remaining int, reset, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if remaining == 0 {
+		if reset.After(b.blocked) {
+			b.blocked = reset
+		}
+		return
+	}
+	if b.rate > 0 {
+		b.refill(now)
+		b.tokens = min(b.tokens, float64(remaining))
+	}
+}
+
+// refill adds the tokens accumulated since the last refill
+func (b *bucket) refill(now time.Time) {
+	if !b.last.IsZero() {
+		elapsed := now.Sub(b.last).Seconds()
+		b.tokens = min(b.tokens+elapsed*b.rate, b.burst)
+	}
+	b.last = now
+}
+
+// parseRateLimitReset parses a X-RateLimit-Reset header expressed either in seconds
+// until the reset or as a Unix timestamp
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds < 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return time.Time{}, false
+	}
+
+	// Values past a billion seconds (2001) can only be timestamps
+	if seconds >= 1e9 {
+		sec, frac := math.Modf(seconds)
+		return time.Unix(int64(sec), int64(frac*float64(time.Second))), true
+	}
+	return now.Add(time.Duration(seconds * float64(time.Second))), true
+}
@@ -0,0 +1,310 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for the rate limiter
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestLimiter returns a limiter reading the time from a fake clock
+func newTestLimiter(limit RateLimit) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	l := NewRateLimiter(limit)
+	l.now = clock.Now
+	return l, clock
+}
+
+func TestEndpointLimitMatches(t *testing.T) {
+	tests := []struct {
+		method  string
+		pattern string
+		reqPath string
+		want    bool
+	}{
+		{pattern: "/v1/transaction", reqPath: "/v1/transaction", want: true},
+		{pattern: "v1/transaction/", reqPath: "/v1/transaction", want: true},
+		{pattern: "/v1/transaction", reqPath: "/v1/transactions", want: false},
+		{pattern: "/v1/transaction", reqPath: "/v1/transaction/txn_1", want: false},
+		{pattern: "/v1/transaction/{id}", reqPath: "/v1/transaction/txn_1", want: true},
+		{pattern: "/v1/transaction/{id}", reqPath: "/v1/transaction", want: false},
+		{pattern: "/v1/transaction/{id}/cancel", reqPath: "/v1/transaction/txn_1/cancel", want: true},
+		{pattern: "/v1/transaction/{id}/cancel", reqPath: "/v1/transaction/txn_1/update", want: false},
+		{pattern: "/v1/transaction/*", reqPath: "/v1/transaction/txn_1/cancel", want: true},
+		{pattern: "/v1/transaction/*", reqPath: "/v1/transaction", want: true},
+		{pattern: "/v1/transaction/*", reqPath: "/v1/kyc", want: false},
+		{pattern: "/v1/*/cancel", reqPath: "/v1/x/cancel", want: false},
+		{method: "POST", pattern: "/v1/transaction", reqPath: "/v1/transaction", want: true},
+		{method: "post", pattern: "/v1/transaction", reqPath: "/v1/transaction", want: true},
+		{method: "GET", pattern: "/v1/transaction", reqPath: "/v1/transaction", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.pattern+" "+tt.reqPath, func(t *testing.T) {
+			e := EndpointLimit{Method: tt.method, Path: tt.pattern}
+			if got := e.matches(http.MethodPost, tt.reqPath); got != tt.want {
+				t.Errorf("matches(POST, %q) = %v, want %v", tt.reqPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterBucketFor(t *testing.T) {
+	l, _ := newTestLimiter(RateLimit{
+		RequestsPerSecond: 10,
+		Endpoints: []EndpointLimit{
+			{Method: http.MethodPost, Path: "/v1/transaction", RequestsPerSecond: 1},
+			{Path: "/v1/transaction/*", RequestsPerSecond: 2},
+		},
+	})
+
+	tests := []struct {
+		method string
+		path   string
+		want   *bucket
+	}{
+		{method: http.MethodPost, path: "/v1/transaction", want: l.endpoints[0].bucket},
+		{method: http.MethodGet, path: "/v1/transaction", want: l.endpoints[1].bucket},
+		{method: http.MethodGet, path: "/v1/transaction/txn_1", want: l.endpoints[1].bucket},
+		{method: http.MethodGet, path: "/v1/kyc", want: l.base},
+	}
+
+	for _, tt := range tests {
+		if got := l.bucketFor(tt.method, tt.path); got != tt.want {
+			t.Errorf("bucketFor(%s, %s) returned the wrong bucket", tt.method, tt.path)
+		}
+	}
+}
+
+func TestBucketTake(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	b := newBucket(10, 2)
+
+	// The burst is available at once, then tokens come every 100ms
+	steps := []struct {
+		at   time.Duration
+		want time.Duration
+	}{
+		{at: 0, want: 0},
+		{at: 0, want: 0},
+		{at: 0, want: 100 * time.Millisecond},
+		{at: 40 * time.Millisecond, want: 60 * time.Millisecond},
+		{at: 100 * time.Millisecond, want: 0},
+		{at: 100 * time.Millisecond, want: 100 * time.Millisecond},
+		{at: 10 * time.Second, want: 0},
+		{at: 10 * time.Second, want: 0},
+		{at: 10 * time.Second, want: 100 * time.Millisecond},
+	}
+
+	for i, step := range steps {
+		if got := b.take(start.Add(step.at)); got != step.want {
+			t.Errorf("step %d: take() at %v = %v, want %v", i, step.at, got, step.want)
+		}
+	}
+}
+
+func TestBucketUnlimited(t *testing.T) {
+	b := newBucket(0, 0)
+	now := time.Unix(1700000000, 0)
+
+	for range 100 {
+		if got := b.take(now); got != 0 {
+			t.Fatalf("take() = %v, want no wait for an unlimited bucket", got)
+		}
+	}
+
+	b.adapt(0, now.Add(time.Second), now)
+	if got := b.take(now); got != time.Second {
+		t.Errorf("take() = %v after the server reported no remaining requests, want 1s", got)
+	}
+}
+
+func TestWaitCanceledDoesNotConsumeTokens(t *testing.T) {
+	l, clock := newTestLimiter(RateLimit{RequestsPerSecond: 1, Burst: 1})
+
+	if err := l.Wait(context.Background(), http.MethodGet, "/v1/kyc"); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for range 3 {
+		if err := l.Wait(ctx, http.MethodGet, "/v1/kyc"); err == nil {
+			t.Fatalf("Wait() with a canceled context succeeded without tokens")
+		}
+	}
+
+	// The canceled waits must not delay the next token
+	clock.Advance(time.Second)
+	done := make(chan error, 1)
+	go func() { done <- l.Wait(context.Background(), http.MethodGet, "/v1/kyc") }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Wait() blocked after the canceled waits, want the refilled token")
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	header := func(remaining, reset string) *http.Response {
+		h := http.Header{}
+		if remaining != "" {
+			h.Set(RateLimitRemainingHeader, remaining)
+		}
+		if reset != "" {
+			h.Set(RateLimitResetHeader, reset)
+		}
+		return &http.Response{Header: h}
+	}
+
+	tests := []struct {
+		name     string
+		limit    RateLimit
+		resp     *http.Response
+		wantWait []time.Duration
+	}{
+		{
+			name:     "no remaining requests with a delta reset",
+			limit:    RateLimit{RequestsPerSecond: 100, Burst: 10},
+			resp:     header("0", "2"),
+			wantWait: []time.Duration{2 * time.Second},
+		},
+		{
+			name:     "no remaining requests with a timestamp reset",
+			limit:    RateLimit{RequestsPerSecond: 100, Burst: 10},
+			resp:     header("0", "1700000005"),
+			wantWait: []time.Duration{5 * time.Second},
+		},
+		{
+			name:     "few remaining requests",
+			limit:    RateLimit{RequestsPerSecond: 1, Burst: 10},
+			resp:     header("2", "60"),
+			wantWait: []time.Duration{0, 0, time.Second},
+		},
+		{
+			name:     "remaining above the burst",
+			limit:    RateLimit{RequestsPerSecond: 1, Burst: 2},
+			resp:     header("50", "60"),
+			wantWait: []time.Duration{0, 0, time.Second},
+		},
+		{
+			name:     "missing reset",
+			limit:    RateLimit{RequestsPerSecond: 100, Burst: 10},
+			resp:     header("0", ""),
+			wantWait: []time.Duration{0},
+		},
+		{
+			name:     "invalid remaining",
+			limit:    RateLimit{RequestsPerSecond: 100, Burst: 10},
+			resp:     header("none", "2"),
+			wantWait: []time.Duration{0},
+		},
+		{
+			name:     "negative remaining",
+			limit:    RateLimit{RequestsPerSecond: 100, Burst: 10},
+			resp:     header("-1", "2"),
+			wantWait: []time.Duration{0},
+		},
+		{
+			name:     "server headers ignored",
+			limit:    RateLimit{RequestsPerSecond: 100, Burst: 10, IgnoreServerHeaders: true},
+			resp:     header("0", "2"),
+			wantWait: []time.Duration{0},
+		},
+		{
+			name:     "nil response",
+			limit:    RateLimit{RequestsPerSecond: 100, Burst: 10},
+			resp:     nil,
+			wantWait: []time.Duration{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, clock := newTestLimiter(tt.limit)
+
+			l.observe(http.MethodGet, "/v1/kyc", tt.resp)
+
+			for i, want := range tt.wantWait {
+				if got := l.base.take(clock.Now()); got != want {
+					t.Errorf("take() #%d = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterObserveEndpoint(t *testing.T) {
+	l, clock := newTestLimiter(RateLimit{
+		RequestsPerSecond: 100,
+		Burst:             10,
+		Endpoints:         []EndpointLimit{{Path: "/v1/transaction/*", RequestsPerSecond: 100, Burst: 10}},
+	})
+
+	resp := func(remaining, reset string) *http.Response {
+		h := http.Header{}
+		h.Set(RateLimitRemainingHeader, remaining)
+		h.Set(RateLimitResetHeader, reset)
+		return &http.Response{Header: h}
+	}
+	l.observe(http.MethodGet, "/v1/transaction/txn_1", resp("0", "3"))
+
+	if got := l.endpoints[0].bucket.take(clock.Now()); got != 3*time.Second {
+		t.Errorf("endpoint take() = %v, want 3s", got)
+	}
+	if got := l.base.take(clock.Now()); got != 0 {
+		t.Errorf("default take() = %v, want the default bucket unaffected", got)
+	}
+
+	// An earlier reset does not shorten the block
+	l.observe(http.MethodGet, "/v1/transaction/txn_1", resp("0", "1"))
+	clock.Advance(2 * time.Second)
+	if got := l.endpoints[0].bucket.take(clock.Now()); got != time.Second {
+		t.Errorf("endpoint take() = %v, want 1s", got)
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		value  string
+		want   time.Time
+		wantOK bool
+	}{
+		{value: "0", want: now, wantOK: true},
+		{value: "30", want: now.Add(30 * time.Second), wantOK: true},
+		{value: "1.5", want: now.Add(1500 * time.Millisecond), wantOK: true},
+		{value: "999999999", want: now.Add(999999999 * time.Second), wantOK: true},
+		{value: "1000000000", want: time.Unix(1000000000, 0), wantOK: true},
+		{value: "1700000060", want: time.Unix(1700000060, 0), wantOK: true},
+		{value: "1700000060.25", want: time.Unix(1700000060, 250000000), wantOK: true},
+		{value: "", wantOK: false},
+		{value: "-1", wantOK: false},
+		{value: "soon", wantOK: false},
+		{value: "NaN", wantOK: false},
+		{value: "+Inf", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseRateLimitReset(tt.value, now)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("parseRateLimitReset(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a request.
+// Requests with this header are retried even when their method is not idempotent.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Default values used by DefaultRetryPolicy
+const (
+	DefaultMaxAttempts = 3
+	DefaultBaseDelay   = 200 * time.Millisecond
+	DefaultMaxDelay    = 5 * time.Second
+	DefaultJitter      = 0.5
+)
+
+// RetryPolicy configures the retries performed by the client on transient failures:
+// network errors and responses with status 429, 502, 503 or 504.
+// Only idempotent requests, or requests carrying an idempotency key, are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, doubled on each further retry
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between attempts, including delays asked by Retry-After
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (0 to 1) of each delay that is randomized to spread retries
+	Jitter float64
+
+	// RetryableStatusCodes overrides the status codes considered transient
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: DefaultMaxAttempts,
+		BaseDelay:   DefaultBaseDelay,
+		MaxDelay:    DefaultMaxDelay,
+		Jitter:      DefaultJitter,
+	}
+}
+
+// defaultRetryableStatusCodes are the status codes retried when none are configured
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// shouldRetry reports whether a failed attempt must be retried.
+// resp is nil when the attempt failed before a response was received.
+func (p *RetryPolicy) shouldRetry(attempt int, method string, header http.Header, resp *http.Response) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if !isIdempotent(method) && header.Get(IdempotencyKeyHeader) == "" {
+		return false
+	}
+
+	if resp == nil {
+		return true
+	}
+
+	codes := p.RetryableStatusCodes
+	if codes == nil {
+		codes = defaultRetryableStatusCodes
+	}
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// delay computes the wait before the next attempt using exponential backoff with jitter.
+// A Retry-After header sent by the server takes precedence over the computed backoff.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				d = p.MaxDelay
+			}
+			return d
+		}
+	}
+
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && d > 0 {
+		jitter := min(p.Jitter, 1)
+		d -= time.Duration(rand.Float64() * jitter * float64(d))
+	}
+
+	return d
+}
+
+// isIdempotent reports whether repeating a request with the given method has no additional effect
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header expressed either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done, whichever happens first
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	withKey := http.Header{IdempotencyKeyHeader: []string{"key"}}
+
+	tests := []struct {
+		name    string
+		policy  *RetryPolicy
+		attempt int
+		method  string
+		header  http.Header
+		status  int // 0 for a network error
+		want    bool
+	}{
+		{name: "nil policy", policy: nil, attempt: 1, method: http.MethodGet, status: 503, want: false},
+		{name: "idempotent method", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodGet, status: 503, want: true},
+		{name: "put is idempotent", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodPut, status: 502, want: true},
+		{name: "delete is idempotent", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodDelete, status: 504, want: true},
+		{name: "post without key", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodPost, status: 503, want: false},
+		{name: "patch without key", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodPatch, status: 503, want: false},
+		{name: "post with key", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodPost, header: withKey, status: 503, want: true},
+		{name: "post with key network error", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodPost, header: withKey, want: true},
+		{name: "network error", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodGet, want: true},
+		{name: "too many requests", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodGet, status: 429, want: true},
+		{name: "client error", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodGet, status: 400, want: false},
+		{name: "internal error", policy: DefaultRetryPolicy(), attempt: 1, method: http.MethodGet, status: 500, want: false},
+		{name: "last attempt", policy: DefaultRetryPolicy(), attempt: DefaultMaxAttempts, method: http.MethodGet, status: 503, want: false},
+		{name: "retries disabled", policy: &RetryPolicy{MaxAttempts: 1}, attempt: 1, method: http.MethodGet, status: 503, want: false},
+		{name: "custom status codes", policy: &RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{500}}, attempt: 1, method: http.MethodGet, status: 500, want: true},
+		{name: "custom status codes replace defaults", policy: &RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{500}}, attempt: 1, method: http.MethodGet, status: 503, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == nil {
+				header = http.Header{}
+			}
+			var resp *http.Response
+			if tt.status != 0 {
+				resp = &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			}
+
+			if got := tt.policy.shouldRetry(tt.attempt, tt.method, header, resp); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     *RetryPolicy
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "first retry", policy: &RetryPolicy{BaseDelay: 100 * time.Millisecond}, attempt: 1, want: 100 * time.Millisecond},
+		{name: "doubled", policy: &RetryPolicy{BaseDelay: 100 * time.Millisecond}, attempt: 3, want: 400 * time.Millisecond},
+		{name: "capped by max delay", policy: &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 250 * time.Millisecond}, attempt: 3, want: 250 * time.Millisecond},
+		{name: "capped after many attempts", policy: &RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}, attempt: 100, want: 5 * time.Second},
+		{name: "retry after seconds", policy: &RetryPolicy{BaseDelay: 100 * time.Millisecond}, attempt: 1, retryAfter: "2", want: 2 * time.Second},
+		{name: "retry after capped by max delay", policy: &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, attempt: 1, retryAfter: "30", want: time.Second},
+		{name: "invalid retry after", policy: &RetryPolicy{BaseDelay: 100 * time.Millisecond}, attempt: 1, retryAfter: "soon", want: 100 * time.Millisecond},
+		{name: "negative retry after", policy: &RetryPolicy{BaseDelay: 100 * time.Millisecond}, attempt: 1, retryAfter: "-1", want: 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			if got := tt.policy.delay(tt.attempt, resp); got != tt.want {
+				t.Errorf("delay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+
+	for range 100 {
+		d := policy.delay(1, nil)
+		if d < 500*time.Millisecond || d > time.Second {
+			t.Fatalf("delay() = %v, want between 500ms and 1s", d)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "empty", value: "", wantOK: false},
+		{name: "seconds", value: "120", want: 2 * time.Minute, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "negative seconds", value: "-5", wantOK: false},
+		{name: "past date", value: "Mon, 02 Jan 2006 15:04:05 GMT", want: 0, wantOK: true},
+		{name: "invalid", value: "later", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	value := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+
+	got, ok := parseRetryAfter(value)
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q) not parsed", value)
+	}
+	if got <= 58*time.Second || got > time.Minute {
+		t.Errorf("parseRetryAfter(%q) = %v, want about 1m", value, got)
+	}
+}
+
+func TestDoRequestRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	c := NewClientWithOptions("key", server.URL, time.Second)
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	var result struct{ OK bool }
+	if err := c.DoRequest(http.MethodGet, "/resource", nil, &result); err != nil {
+		t.Fatalf("DoRequest() error = %v", err)
+	}
+	if !result.OK || calls.Load() != 3 {
+		t.Errorf("got ok=%v after %d calls, want ok=true after 3 calls", result.OK, calls.Load())
+	}
+}
+
+func TestDoRequestDoesNotRetryPostWithoutKey(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := NewClientWithOptions("key", server.URL, time.Second)
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	err := c.DoRequest(http.MethodPost, "/resource", map[string]string{}, nil)
+	if !isStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("DoRequest() error = %v, want a 503 error", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("got %d calls, want 1", calls.Load())
+	}
+
+	calls.Store(0)
+	err = c.DoRequest(http.MethodPost, "/resource", map[string]string{}, nil, WithIdempotencyKey("key"))
+	if !isStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("DoRequest() error = %v, want a 503 error", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("got %d calls with an idempotency key, want 3", calls.Load())
+	}
+}
+
+func TestDoRequestCanceledDuringRetryWait(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := NewClientWithOptions("key", server.URL, time.Second)
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Minute}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := c.DoRequestContext(ctx, http.MethodGet, "/resource", nil, nil)
+	if !errors.Is(err, ErrRequestCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("DoRequestContext() error = %v, want ErrRequestCanceled wrapping context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("DoRequestContext() returned after %v, want it to stop waiting on cancellation", elapsed)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("got %d calls, want 1", calls.Load())
+	}
+}
+
+// isStatus reports whether err is an *Error with the given status code
+func isStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
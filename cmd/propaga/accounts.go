@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// accountCommands operate on the account service
+var accountCommands = group{
+	name:    "accounts",
+	summary: "list, suspend and activate accounts",
+	commands: []command{
+		{name: "list", summary: "list accounts", define: accountsList},
+		{name: "get", args: "<id>", summary: "get an account by ID", define: accountsGet},
+		{name: "suspend", args: "<id>", summary: "suspend an account", define: accountsSuspend},
+		{name: "activate", args: "<id>", summary: "activate an account", define: accountsActivate},
+	},
+}
+
+func accountsList(fs *flag.FlagSet) runFunc {
+	lf := addListFlags(fs)
+	customer := fs.String("customer", "", "filter by customer ID")
+	status := fs.String("status", "", "filter by status")
+
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 0); err != nil {
+			return err
+		}
+
+		params := &models.AccountListParams{
+			Limit:      *lf.limit,
+			Offset:     *lf.offset,
+			CustomerID: *customer,
+			Status:     *status,
+			StartDate:  lf.start,
+			EndDate:    lf.end,
+		}
+		var data []models.Account
+		if *lf.all {
+			all, err := collect(a.client.Accounts.ListAll(a.ctx, params, nil))
+			if err != nil {
+				return err
+			}
+			data = all
+		} else {
+			result, err := a.client.Accounts.ListContext(a.ctx, params)
+			if err != nil {
+				return err
+			}
+			data = result.Data
+		}
+
+		return a.out.print(data, accountTable(data...))
+	}
+}
+
+func accountsGet(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		account, err := a.client.Accounts.GetContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.out.print(account, accountTable(*account))
+	}
+}
+
+func accountsSuspend(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		account, err := a.client.Accounts.SuspendContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.out.print(account, accountTable(*account))
+	}
+}
+
+func accountsActivate(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		account, err := a.client.Accounts.ActivateContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.out.print(account, accountTable(*account))
+	}
+}
+
+// accountTable builds the table of accounts. Contact details are only available in the JSON output.
+func accountTable(accounts ...models.Account) *table {
+	t := &table{header: []string{"ID", "CUSTOMER", "NAME", "STATUS", "CREDIT LIMIT", "BALANCE", "CREATED"}}
+	for _, account := range accounts {
+		t.add(account.ID, account.CustomerID, account.Name, account.Status,
+			account.CreditLimit.String(), account.CurrentBalance.String(), account.CreatedAt.String())
+	}
+	return t
+}
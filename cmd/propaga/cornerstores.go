@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// cornerStoreCommands operate on the corner store service
+var cornerStoreCommands = group{
+	name:    "corner-stores",
+	summary: "list and inspect corner stores",
+	commands: []command{
+		{name: "list", summary: "list corner stores", define: cornerStoresList},
+		{name: "get", args: "<id>", summary: "get a corner store by ID", define: cornerStoresGet},
+		{name: "info", args: "<external-id>", summary: "get the credit information of a corner store by external ID", define: cornerStoresInfo},
+	},
+}
+
+func cornerStoresList(fs *flag.FlagSet) runFunc {
+	lf := addListFlags(fs)
+	status := fs.String("status", "", "filter by status")
+	city := fs.String("city", "", "filter by city")
+	state := fs.String("state", "", "filter by state")
+
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 0); err != nil {
+			return err
+		}
+
+		params := &models.CornerStoreListParams{
+			Limit:     *lf.limit,
+			Offset:    *lf.offset,
+			Status:    *status,
+			City:      *city,
+			State:     *state,
+			StartDate: lf.start,
+			EndDate:   lf.end,
+		}
+		var data []models.CornerStore
+		if *lf.all {
+			all, err := collect(a.client.CornerStores.ListAll(a.ctx, params, nil))
+			if err != nil {
+				return err
+			}
+			data = all
+		} else {
+			result, err := a.client.CornerStores.ListContext(a.ctx, params)
+			if err != nil {
+				return err
+			}
+			data = result.Data
+		}
+
+		return a.out.print(data, cornerStoreTable(data...))
+	}
+}
+
+func cornerStoresGet(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		store, err := a.client.CornerStores.GetContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.out.print(store, cornerStoreTable(*store))
+	}
+}
+
+func cornerStoresInfo(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		externalID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid external ID %q: must be a number", args[0])
+		}
+
+		info, err := a.client.CornerStores.GetCornerStoreInfoByExternalIdContext(a.ctx, externalID)
+		if err != nil {
+			return err
+		}
+
+		t := &table{header: []string{"CORNER STORE", "USER", "STATUS", "CREDIT AVAILABLE"}}
+		t.add(info.CornerStoreId, info.UserId, info.Status, info.CreditLimitAvailable.String())
+		return a.out.print(info, t)
+	}
+}
+
+// cornerStoreTable builds the table of corner stores
+func cornerStoreTable(stores ...models.CornerStore) *table {
+	t := &table{header: []string{"ID", "NAME", "STATUS", "CITY", "STATE", "PHONE", "CREATED"}}
+	for _, store := range stores {
+		t.add(store.ID, store.Name, store.Status, store.City, store.State, store.PhoneNumber, store.CreatedAt.String())
+	}
+	return t
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// kycCommands operate on the KYC service
+var kycCommands = group{
+	name:    "kyc",
+	summary: "list and review KYC verifications",
+	commands: []command{
+		{name: "list", summary: "list KYC verifications", define: kycList},
+		{name: "get", args: "<id>", summary: "get a KYC verification by ID", define: kycGet},
+		{name: "verify", args: "<id>", summary: "mark a KYC verification as verified", define: kycVerify},
+		{name: "reject", args: "<id>", summary: "reject a KYC verification", define: kycReject},
+	},
+}
+
+func kycList(fs *flag.FlagSet) runFunc {
+	lf := addListFlags(fs)
+	customer := fs.String("customer", "", "filter by customer ID")
+	status := fs.String("status", "", "filter by status")
+
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 0); err != nil {
+			return err
+		}
+
+		params := &models.KYCListParams{
+			Limit:      *lf.limit,
+			Offset:     *lf.offset,
+			CustomerID: *customer,
+			Status:     *status,
+			StartDate:  lf.start,
+			EndDate:    lf.end,
+		}
+		var data []models.KYC
+		if *lf.all {
+			all, err := collect(a.client.KYC.ListAll(a.ctx, params, nil))
+			if err != nil {
+				return err
+			}
+			data = all
+		} else {
+			result, err := a.client.KYC.ListContext(a.ctx, params)
+			if err != nil {
+				return err
+			}
+			data = result.Data
+		}
+
+		return a.out.print(data, kycTable(data...))
+	}
+}
+
+func kycGet(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		kyc, err := a.client.KYC.GetContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.out.print(kyc, kycTable(*kyc))
+	}
+}
+
+func kycVerify(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		kyc, err := a.client.KYC.VerifyContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.out.print(kyc, kycTable(*kyc))
+	}
+}
+
+func kycReject(fs *flag.FlagSet) runFunc {
+	reason := fs.String("reason", "", "reason of the rejection (required)")
+
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+		if *reason == "" {
+			fs.Usage()
+			return errUsage
+		}
+
+		kyc, err := a.client.KYC.RejectContext(a.ctx, args[0], *reason)
+		if err != nil {
+			return err
+		}
+		return a.out.print(kyc, kycTable(*kyc))
+	}
+}
+
+// kycTable builds the table of KYC verifications. Personal data such as the
+// document ID and the date of birth are only available in the JSON output.
+func kycTable(verifications ...models.KYC) *table {
+	t := &table{header: []string{"ID", "CUSTOMER", "STATUS", "DOCUMENT TYPE", "FULL NAME", "CREATED", "REJECT REASON"}}
+	for _, kyc := range verifications {
+		t.add(kyc.ID, kyc.CustomerID, kyc.Status, kyc.DocumentType, kyc.FullName, kyc.CreatedAt.String(), kyc.RejectReason)
+	}
+	return t
+}
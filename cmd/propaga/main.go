@@ -0,0 +1,275 @@
+// Command propaga is a command-line client of the Propaga API for support and operations.
+//
+// Usage:
+//
+//	propaga [global flags] <group> <command> [flags] [arguments]
+//
+// The groups mirror the services of the SDK: transactions, corner-stores, kyc and accounts.
+// The API key and the environment are read from the flags or from the PROPAGA_API_KEY,
+// PROPAGA_ENVIRONMENT, PROPAGA_BASE_URL and PROPAGA_OUTPUT environment variables.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"os/signal"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// Environment variables providing the defaults of the global flags
+const (
+	envAPIKey      = "PROPAGA_API_KEY"
+	envEnvironment = "PROPAGA_ENVIRONMENT"
+	envBaseURL     = "PROPAGA_BASE_URL"
+	envOutput      = "PROPAGA_OUTPUT"
+)
+
+// errUsage reports invalid arguments, its usage has already been printed
+var errUsage = errors.New("invalid usage")
+
+// app holds the state shared by the commands
+type app struct {
+	ctx    context.Context
+	client *propaga.Client
+	out    *printer
+	stdin  io.Reader
+	stderr io.Writer
+}
+
+// runFunc runs a command with its positional arguments
+type runFunc func(a *app, args []string) error
+
+// command is a subcommand of a group
+type command struct {
+	name    string
+	args    string
+	summary string
+
+	// define registers the flags of the command on fs and returns the function running it
+	define func(fs *flag.FlagSet) runFunc
+}
+
+// group is a set of commands operating on the same service
+type group struct {
+	name     string
+	summary  string
+	commands []command
+}
+
+// groups lists the groups of commands, in the order of the usage
+var groups = []group{
+	transactionCommands,
+	cornerStoreCommands,
+	kycCommands,
+	accountCommands,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit code
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("propaga", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	apiKey := fs.String("api-key", os.Getenv(envAPIKey), "API key, defaults to $"+envAPIKey)
+	env := fs.String("env", envOr(envEnvironment, string(propaga.EnvironmentProduction)), "environment, production or staging, defaults to $"+envEnvironment)
+	baseURL := fs.String("base-url", os.Getenv(envBaseURL), "base URL overriding the environment, defaults to $"+envBaseURL)
+	output := fs.String("o", envOr(envOutput, formatTable), "output format, table, json or csv, defaults to $"+envOutput)
+	timeout := fs.Duration("timeout", client.DefaultTimeout, "timeout of each request")
+	fs.Usage = func() { usage(stderr, fs) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	out, err := newPrinter(stdout, *output)
+	if err != nil {
+		fmt.Fprintf(stderr, "propaga: %v\n", err)
+		return 2
+	}
+
+	opts := []propaga.Option{propaga.WithTimeout(*timeout), propaga.WithUserAgent("propaga-cli")}
+	switch propaga.Environment(*env) {
+	case propaga.EnvironmentProduction, propaga.EnvironmentStaging:
+		opts = append(opts, propaga.WithEnvironment(propaga.Environment(*env)))
+	default:
+		fmt.Fprintf(stderr, "propaga: unknown environment %q\n", *env)
+		return 2
+	}
+	if *baseURL != "" {
+		opts = append(opts, propaga.WithBaseURL(*baseURL))
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	if *apiKey == "" {
+		fmt.Fprintf(stderr, "propaga: missing API key, set -api-key or $%s\n", envAPIKey)
+		return 2
+	}
+
+	a := &app{
+		ctx:    ctx,
+		client: propaga.New(*apiKey, opts...),
+		out:    out,
+		stdin:  stdin,
+		stderr: stderr,
+	}
+	if err := a.run(fs.Args()); err != nil {
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		fmt.Fprintf(stderr, "propaga: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// run dispatches args to the matching command
+func (a *app) run(args []string) error {
+	g := findGroup(args[0])
+	if g == nil {
+		fmt.Fprintf(a.stderr, "propaga: unknown group %q\n", args[0])
+		return errUsage
+	}
+	if len(args) < 2 {
+		g.usage(a.stderr)
+		return errUsage
+	}
+
+	for _, cmd := range g.commands {
+		if cmd.name != args[1] {
+			continue
+		}
+
+		fs := flag.NewFlagSet(g.name+" "+cmd.name, flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		run := cmd.define(fs)
+		fs.Usage = func() {
+			fmt.Fprintf(a.stderr, "Usage: propaga %s %s [flags] %s\n\n%s\n", g.name, cmd.name, cmd.args, cmd.summary)
+			fs.PrintDefaults()
+		}
+		if err := fs.Parse(args[2:]); err != nil {
+			return errUsage
+		}
+		return run(a, fs.Args())
+	}
+
+	fmt.Fprintf(a.stderr, "propaga: unknown command %q\n", args[1])
+	g.usage(a.stderr)
+	return errUsage
+}
+
+// findGroup returns the group named name, nil when there is none
+func findGroup(name string) *group {
+	for i := range groups {
+		if groups[i].name == name {
+			return &groups[i]
+		}
+	}
+	return nil
+}
+
+// usage prints the usage of the tool
+func usage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "Usage: propaga [global flags] <group> <command> [flags] [arguments]\n\nGroups:\n")
+	for _, g := range groups {
+		fmt.Fprintf(w, "  %-15s %s\n", g.name, g.summary)
+	}
+	fmt.Fprintf(w, "\nGlobal flags:\n")
+	fs.PrintDefaults()
+}
+
+// usage prints the commands of the group
+func (g *group) usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: propaga %s <command> [flags] [arguments]\n\nCommands:\n", g.name)
+	for _, cmd := range g.commands {
+		fmt.Fprintf(w, "  %-13s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// requireArgs checks that the command received exactly n arguments
+func requireArgs(fs *flag.FlagSet, args []string, n int) error {
+	if len(args) != n {
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// listFlags are the pagination and date flags shared by the list commands
+type listFlags struct {
+	limit  *int
+	offset *int
+	all    *bool
+	start  models.Date
+	end    models.Date
+}
+
+// addListFlags registers the list flags on fs
+func addListFlags(fs *flag.FlagSet) *listFlags {
+	lf := &listFlags{
+		limit:  fs.Int("limit", 0, "maximum number of results, the API default when zero"),
+		offset: fs.Int("offset", 0, "number of results to skip"),
+		all:    fs.Bool("all", false, "fetch every page, -limit being the page size"),
+	}
+	fs.TextVar(&lf.start, "start", models.Date{}, "only results from this date, YYYY-MM-DD")
+	fs.TextVar(&lf.end, "end", models.Date{}, "only results until this date, YYYY-MM-DD")
+	return lf
+}
+
+// collect gathers the items of seq, stopping at the first error
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// decodeData decodes the JSON document at path into v, reading stdin when path is "-"
+func (a *app) decodeData(path string, v any) error {
+	if path == "" {
+		return fmt.Errorf("missing -data, the path of a JSON document or - for stdin")
+	}
+
+	r := a.stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if err := jsonDecode(r, v); err != nil {
+		return fmt.Errorf("error decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// envOr returns the value of the environment variable key, fallback when it is unset
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+	"github.com/diogenes-moreira/propaga-sdk/propagatest"
+)
+
+// result is the outcome of a run of the command line
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+// runCLI runs the command line args with stdin and returns its outcome
+func runCLI(t *testing.T, stdin string, args ...string) result {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+// newServer starts a fake API holding a transaction and clears the environment variables of the CLI
+func newServer(t *testing.T) (*propagatest.Server, models.Transaction) {
+	t.Helper()
+	for _, key := range []string{envAPIKey, envEnvironment, envBaseURL, envOutput} {
+		t.Setenv(key, "")
+	}
+
+	server := propagatest.NewServer()
+	t.Cleanup(server.Close)
+	tx := server.AddTransaction(models.Transaction{
+		CornerStoreId:           "cs-1",
+		WholesalerTransactionId: "order-1",
+		TotalAmount:             models.MXN(10000),
+		Products:                []models.Product{{ExternalSKU: "sku-1", Name: "Product", Quantity: 1}},
+	})
+	return server, tx
+}
+
+func TestRunFlagEnvPrecedence(t *testing.T) {
+	server, tx := newServer(t)
+	unreachable := "http://127.0.0.1:1"
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		args     []string
+		wantCode int
+		wantJSON bool
+	}{
+		{
+			name: "flags only",
+			args: []string{"-api-key", server.APIKey, "-base-url", server.URL},
+		},
+		{
+			name:     "environment only",
+			env:      map[string]string{envAPIKey: server.APIKey, envBaseURL: server.URL, envOutput: formatJSON},
+			wantJSON: true,
+		},
+		{
+			name: "API key flag over the environment",
+			env:  map[string]string{envAPIKey: "wrong-key", envBaseURL: server.URL},
+			args: []string{"-api-key", server.APIKey},
+		},
+		{
+			name:     "wrong API key from the environment",
+			env:      map[string]string{envAPIKey: "wrong-key", envBaseURL: server.URL},
+			wantCode: 1,
+		},
+		{
+			name: "base URL flag over the environment",
+			env:  map[string]string{envAPIKey: server.APIKey, envBaseURL: unreachable},
+			args: []string{"-base-url", server.URL},
+		},
+		{
+			name:     "output flag over the environment",
+			env:      map[string]string{envAPIKey: server.APIKey, envBaseURL: server.URL, envOutput: formatCSV},
+			args:     []string{"-o", formatJSON},
+			wantJSON: true,
+		},
+		{
+			name: "environment flag over the environment",
+			env:  map[string]string{envAPIKey: server.APIKey, envBaseURL: server.URL, envEnvironment: "sandbox"},
+			args: []string{"-env", "staging"},
+		},
+		{
+			name:     "unknown environment",
+			env:      map[string]string{envAPIKey: server.APIKey, envBaseURL: server.URL, envEnvironment: "sandbox"},
+			wantCode: 2,
+		},
+		{
+			name:     "unknown output format",
+			env:      map[string]string{envAPIKey: server.APIKey, envBaseURL: server.URL, envOutput: "yaml"},
+			wantCode: 2,
+		},
+		{
+			name:     "missing API key",
+			args:     []string{"-base-url", server.URL},
+			wantCode: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			args := append(tt.args, "transactions", "get", tx.TransactionId)
+			got := runCLI(t, "", args...)
+
+			if got.code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d, stderr %q", got.code, tt.wantCode, got.stderr)
+			}
+			if tt.wantCode != 0 {
+				if got.stderr == "" {
+					t.Errorf("no error printed for exit code %d", got.code)
+				}
+				return
+			}
+			if isJSON := json.Valid([]byte(got.stdout)); isJSON != tt.wantJSON || !strings.Contains(got.stdout, tx.TransactionId) {
+				t.Errorf("stdout = %q, want the transaction as JSON %v", got.stdout, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestRunDispatch(t *testing.T) {
+	server, tx := newServer(t)
+	t.Setenv(envAPIKey, server.APIKey)
+	t.Setenv(envBaseURL, server.URL)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStderr string
+		wantStdout string
+	}{
+		{name: "no arguments", args: nil, wantCode: 2, wantStderr: "Usage: propaga [global flags]"},
+		{name: "unknown global flag", args: []string{"-verbose", "transactions", "list"}, wantCode: 2, wantStderr: "flag provided but not defined"},
+		{name: "unknown group", args: []string{"orders", "list"}, wantCode: 2, wantStderr: `unknown group "orders"`},
+		{name: "missing command", args: []string{"transactions"}, wantCode: 2, wantStderr: "Usage: propaga transactions <command>"},
+		{name: "unknown command", args: []string{"kyc", "approve"}, wantCode: 2, wantStderr: `unknown command "approve"`},
+		{name: "unknown command flag", args: []string{"transactions", "get", "-all", tx.TransactionId}, wantCode: 2, wantStderr: "Usage: propaga transactions get"},
+		{name: "missing argument", args: []string{"transactions", "get"}, wantCode: 2, wantStderr: "Usage: propaga transactions get [flags] <id>"},
+		{name: "extra argument", args: []string{"transactions", "list", "txn_1"}, wantCode: 2, wantStderr: "Usage: propaga transactions list"},
+		{name: "not found", args: []string{"transactions", "get", "txn_404"}, wantCode: 1, wantStderr: "propaga: "},
+		{name: "transactions", args: []string{"transactions", "get-external", "order-1"}, wantStdout: tx.TransactionId},
+		{name: "corner stores", args: []string{"corner-stores", "list"}, wantStdout: "ID"},
+		{name: "kyc", args: []string{"kyc", "list"}, wantStdout: "ID"},
+		{name: "accounts", args: []string{"accounts", "list"}, wantStdout: "ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCLI(t, "", tt.args...)
+
+			if got.code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d, stderr %q", got.code, tt.wantCode, got.stderr)
+			}
+			if !strings.Contains(got.stderr, tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", got.stderr, tt.wantStderr)
+			}
+			if !strings.Contains(got.stdout, tt.wantStdout) {
+				t.Errorf("stdout = %q, want it to contain %q", got.stdout, tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestRunFailureExitCode(t *testing.T) {
+	server, _ := newServer(t)
+	server.FailNext(http.MethodGet, "/v1/transaction", http.StatusInternalServerError)
+
+	got := runCLI(t, "", "-api-key", server.APIKey, "-base-url", server.URL, "-timeout", time.Second.String(), "transactions", "list")
+
+	if got.code != 1 || got.stdout != "" || !strings.Contains(got.stderr, "500") {
+		t.Errorf("run() = %+v, want exit code 1 with the API error", got)
+	}
+}
+
+func TestRunOutputFormats(t *testing.T) {
+	server, tx := newServer(t)
+	t.Setenv(envAPIKey, server.APIKey)
+	t.Setenv(envBaseURL, server.URL)
+	server.AddTransaction(models.Transaction{CornerStoreId: "cs-2", WholesalerTransactionId: "order-2", TotalAmount: models.MXN(2550)})
+
+	t.Run("table", func(t *testing.T) {
+		got := runCLI(t, "", "-o", formatTable, "transactions", "list")
+		if got.code != 0 {
+			t.Fatalf("exit code = %d, stderr %q", got.code, got.stderr)
+		}
+
+		lines := strings.Split(strings.TrimSuffix(got.stdout, "\n"), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("table = %q, want a header and 2 rows", got.stdout)
+		}
+		header, first := strings.Fields(lines[0]), strings.Fields(lines[1])
+		if header[0] != "ID" || header[1] != "CORNER" || first[0] != tx.TransactionId || first[1] != "cs-1" {
+			t.Errorf("table = %q", got.stdout)
+		}
+		// Columns are aligned on the header
+		if strings.Index(lines[0], "STATUS") != strings.Index(lines[1], string(models.TransactionStatusPending)) {
+			t.Errorf("table columns not aligned:\n%s", got.stdout)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		got := runCLI(t, "", "-o", formatJSON, "transactions", "list")
+		if got.code != 0 {
+			t.Fatalf("exit code = %d, stderr %q", got.code, got.stderr)
+		}
+
+		var transactions []models.Transaction
+		if err := json.Unmarshal([]byte(got.stdout), &transactions); err != nil {
+			t.Fatalf("output is not a JSON list of transactions: %v\n%s", err, got.stdout)
+		}
+		if len(transactions) != 2 || transactions[1].WholesalerTransactionId != "order-2" || transactions[1].TotalAmount != models.MXN(2550) {
+			t.Errorf("transactions = %+v", transactions)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		got := runCLI(t, "", "-o", formatCSV, "transactions", "list")
+		if got.code != 0 {
+			t.Fatalf("exit code = %d, stderr %q", got.code, got.stderr)
+		}
+
+		records, err := csv.NewReader(strings.NewReader(got.stdout)).ReadAll()
+		if err != nil {
+			t.Fatalf("output is not CSV: %v\n%s", err, got.stdout)
+		}
+		want := []string{"ID", "CORNER STORE", "STATUS", "WHOLESALER ID", "TOTAL", "TOTAL WITH INTERESTS", "DELIVERY"}
+		if len(records) != 3 || strings.Join(records[0], ",") != strings.Join(want, ",") {
+			t.Fatalf("records = %v", records)
+		}
+		if row := records[2]; row[1] != "cs-2" || row[3] != "order-2" || row[4] != models.MXN(2550).String() {
+			t.Errorf("row = %v", row)
+		}
+	})
+}
+
+func TestRunCreateFromStdin(t *testing.T) {
+	server, _ := newServer(t)
+	t.Setenv(envAPIKey, server.APIKey)
+	t.Setenv(envBaseURL, server.URL)
+
+	document := func(product string) string {
+		return `{"cornerStoreId":"cs-9","wholesalerTransactionId":"order-9","totalAmount":100,` +
+			`"deliveryDate":"2025-01-02","products":[` + product + `]}`
+	}
+
+	got := runCLI(t, document(`{"externalSKU":"sku-1","name":"Product","quantity":2}`), "-o", formatJSON, "transactions", "create", "-data", "-")
+	if got.code != 0 {
+		t.Fatalf("exit code = %d, stderr %q", got.code, got.stderr)
+	}
+	var created models.Transaction
+	if err := json.Unmarshal([]byte(got.stdout), &created); err != nil || created.WholesalerTransactionId != "order-9" || len(created.Products) != 1 {
+		t.Errorf("created = %+v, %v", created, err)
+	}
+
+	tests := []struct {
+		name      string
+		document  string
+		wantError string
+	}{
+		{name: "unknown top-level field", document: `{"storeId":"cs-9"}`, wantError: `unknown field "storeId"`},
+		{name: "unknown nested field", document: document(`{"name":"Product","quantity":2,"qty":2}`), wantError: "products[0].qty"},
+		{name: "not JSON", document: "cornerStoreId=cs-9", wantError: "error decoding -"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := len(server.Requests())
+
+			got := runCLI(t, tt.document, "transactions", "create", "-data", "-")
+
+			if got.code != 1 || !strings.Contains(got.stderr, tt.wantError) {
+				t.Errorf("run() = %+v, want exit code 1 with %q", got, tt.wantError)
+			}
+			if len(server.Requests()) != requests {
+				t.Errorf("an invalid document was sent to the API")
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// Output formats
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// table is the tabular representation of a result
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// add appends a row to the table
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+// printer writes the results of the commands in the selected format
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// newPrinter creates a printer writing to w in format
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return &printer{w: w, format: format}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q", format)
+}
+
+// print writes v as JSON or t as a table or CSV, depending on the format
+func (p *printer) print(v any, t *table) error {
+	switch p.format {
+	case formatJSON:
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+
+	case formatCSV:
+		w := csv.NewWriter(p.w)
+		if err := w.Write(t.header); err != nil {
+			return err
+		}
+		if err := w.WriteAll(t.rows); err != nil {
+			return err
+		}
+		return w.Error()
+
+	default:
+		w := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+// jsonDecode decodes a single JSON document from r into v, rejecting unknown fields.
+// DisallowUnknownFields does not reach the models decoding themselves, such as the products,
+// whose unknown fields are kept in their Extra and reported by models.CheckUnknownFields.
+func jsonDecode(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	return models.CheckUnknownFields(v)
+}
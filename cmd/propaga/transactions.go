@@ -0,0 +1,208 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// transactionCommands operate on the transactions service
+var transactionCommands = group{
+	name:    "transactions",
+	summary: "list, inspect and operate transactions",
+	commands: []command{
+		{name: "list", summary: "list transactions", define: transactionsList},
+		{name: "get", args: "<id>", summary: "get a transaction by ID", define: transactionsGet},
+		{name: "get-external", args: "<external-id>", summary: "get a transaction by wholesaler transaction ID", define: transactionsGetExternal},
+		{name: "create", summary: "create a transaction from a JSON document", define: transactionsCreate},
+		{name: "update", args: "<id>", summary: "update a transaction", define: transactionsUpdate},
+		{name: "cancel", args: "<id>", summary: "cancel a transaction", define: transactionsCancel},
+		{name: "link", args: "<id>", summary: "create a transaction link from a JSON document", define: transactionsLink},
+		{name: "pending", summary: "list the pending transactions", define: transactionsPending},
+	},
+}
+
+func transactionsList(fs *flag.FlagSet) runFunc {
+	lf := addListFlags(fs)
+	customer := fs.String("customer", "", "filter by customer ID")
+	status := fs.String("status", "", "filter by status")
+
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 0); err != nil {
+			return err
+		}
+
+		params := &models.TransactionListParams{
+			Limit:      *lf.limit,
+			Offset:     *lf.offset,
+			CustomerID: *customer,
+			Status:     models.TransactionStatus(*status),
+			StartDate:  lf.start,
+			EndDate:    lf.end,
+		}
+		var data []models.Transaction
+		if *lf.all {
+			all, err := collect(a.client.Transactions.ListAll(a.ctx, params, nil))
+			if err != nil {
+				return err
+			}
+			data = all
+		} else {
+			result, err := a.client.Transactions.ListContext(a.ctx, params)
+			if err != nil {
+				return err
+			}
+			data = result.Data
+		}
+
+		return a.out.print(data, transactionTable(data...))
+	}
+}
+
+func transactionsGet(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		tx, err := a.client.Transactions.GetContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.out.print(tx, transactionTable(*tx))
+	}
+}
+
+func transactionsGetExternal(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		tx, err := a.client.Transactions.GetByExternalIDContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.out.print(tx, transactionTable(*tx))
+	}
+}
+
+func transactionsCreate(fs *flag.FlagSet) runFunc {
+	data := fs.String("data", "", "path of the JSON parameters, - for stdin")
+	key := fs.String("idempotency-key", "", "idempotency key, derived from the wholesaler transaction ID by default")
+
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 0); err != nil {
+			return err
+		}
+
+		params := &models.TransactionCreateParams{}
+		if err := a.decodeData(*data, params); err != nil {
+			return err
+		}
+		params.IdempotencyKey = *key
+
+		tx, err := a.client.Transactions.CreateContext(a.ctx, params)
+		if err != nil {
+			return err
+		}
+		return a.out.print(tx, transactionTable(*tx))
+	}
+}
+
+func transactionsUpdate(fs *flag.FlagSet) runFunc {
+	data := fs.String("data", "", "path of the JSON parameters, - for stdin")
+	status := fs.String("status", "", "new status, overriding the one of -data")
+
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		params := &models.TransactionUpdateParams{}
+		if *data != "" {
+			if err := a.decodeData(*data, params); err != nil {
+				return err
+			}
+		}
+		if *status != "" {
+			params.Status = models.TransactionStatus(*status)
+		}
+
+		tx, err := a.client.Transactions.UpdateContext(a.ctx, args[0], params)
+		if err != nil {
+			return err
+		}
+		return a.out.print(tx, transactionTable(*tx))
+	}
+}
+
+func transactionsCancel(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		tx, err := a.client.Transactions.CancelContext(a.ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return a.out.print(tx, transactionTable(*tx))
+	}
+}
+
+func transactionsLink(fs *flag.FlagSet) runFunc {
+	data := fs.String("data", "", "path of the JSON parameters, - for stdin")
+	key := fs.String("idempotency-key", "", "idempotency key, derived from the wholesaler transaction ID by default")
+
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 1); err != nil {
+			return err
+		}
+
+		params := &models.TransactionLinkParams{}
+		if err := a.decodeData(*data, params); err != nil {
+			return err
+		}
+		params.IdempotencyKey = *key
+
+		link, err := a.client.Transactions.CreateTransactionLinkContext(a.ctx, args[0], params)
+		if err != nil {
+			return err
+		}
+
+		t := &table{header: []string{"TRANSACTION", "LINK"}}
+		t.add(link.TransactionId, link.Link)
+		return a.out.print(link, t)
+	}
+}
+
+func transactionsPending(fs *flag.FlagSet) runFunc {
+	return func(a *app, args []string) error {
+		if err := requireArgs(fs, args, 0); err != nil {
+			return err
+		}
+
+		pending, err := a.client.Transactions.GetPendingTransactionsContext(a.ctx)
+		if err != nil {
+			return err
+		}
+
+		t := &table{header: []string{"ID", "CORNER STORE", "WHOLESALER ID", "TOTAL", "TOTAL WITH INTERESTS", "DELIVERY"}}
+		for _, tx := range pending.Transactions {
+			t.add(tx.Id, tx.CornerStoreId, tx.WholesalerTransactionId, tx.TotalAmount.String(),
+				tx.TotalAmountWithInterests.String(), tx.DeliveryDate.String())
+		}
+		return a.out.print(pending, t)
+	}
+}
+
+// transactionTable builds the table of transactions
+func transactionTable(transactions ...models.Transaction) *table {
+	t := &table{header: []string{"ID", "CORNER STORE", "STATUS", "WHOLESALER ID", "TOTAL", "TOTAL WITH INTERESTS", "DELIVERY"}}
+	for _, tx := range transactions {
+		t.add(tx.TransactionId, tx.CornerStoreId, tx.TransactionStatus.String(), tx.WholesalerTransactionId,
+			tx.TotalAmount.String(), tx.TotalAmountWithInterests.String(), tx.DeliveryDate.String())
+	}
+	return t
+}
@@ -1,8 +1,11 @@
 package cornerstore
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
+	"strconv"
 
 	"github.com/diogenes-moreira/propaga-sdk/client"
 	"github.com/diogenes-moreira/propaga-sdk/models"
@@ -22,10 +25,21 @@ func NewService(client *client.Client) *Service {
 
 // List retrieves a list of corner stores based on the specified parameters
 func (s *Service) List(params *models.CornerStoreListParams) (*models.CornerStoreListResponse, error) {
+	return s.ListContext(context.Background(), params)
+}
+
+// ListContext is like List but honors ctx for cancellation and deadlines
+func (s *Service) ListContext(ctx context.Context, params *models.CornerStoreListParams) (*models.CornerStoreListResponse, error) {
 	result := &models.CornerStoreListResponse{}
 
 	// Endpoint placeholder - should be updated when documentation is available
-	err := s.client.DoRequest(http.MethodGet, "/v1/corner-store", params, result)
+	query, err := client.EncodeQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("error listing corner stores: %w", err)
+	}
+
+	err = s.client.DoRequestContext(ctx, http.MethodGet, "/v1/corner-store", nil, result,
+		client.WithQuery(query), client.WithOperation("cornerstores", "List"))
 	if err != nil {
 		return nil, fmt.Errorf("error listing corner stores: %w", err)
 	}
@@ -33,13 +47,39 @@ func (s *Service) List(params *models.CornerStoreListParams) (*models.CornerStor
 	return result, nil
 }
 
+// ListAll returns a sequence over every corner store matching params, fetching pages lazily.
+// params.Offset is used as the starting point and params.Limit as page size unless opts sets one.
+func (s *Service) ListAll(ctx context.Context, params *models.CornerStoreListParams, opts *client.IteratorOptions) iter.Seq2[models.CornerStore, error] {
+	return s.ListIterator(ctx, params, opts).All()
+}
+
+// ListIterator returns an iterator over every corner store matching params, fetching pages lazily
+func (s *Service) ListIterator(ctx context.Context, params *models.CornerStoreListParams, opts *client.IteratorOptions) *client.Iterator[models.CornerStore] {
+	pageFields := func(p *models.CornerStoreListParams) (*int, *int) { return &p.Limit, &p.Offset }
+	list := func(ctx context.Context, page *models.CornerStoreListParams) ([]models.CornerStore, int, error) {
+		result, err := s.ListContext(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data, result.TotalCount, nil
+	}
+
+	return client.ListIterator(ctx, params, pageFields, list, opts)
+}
+
 // Get retrieves a specific corner store by its ID
 func (s *Service) Get(id string) (*models.CornerStore, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but honors ctx for cancellation and deadlines
+func (s *Service) GetContext(ctx context.Context, id string) (*models.CornerStore, error) {
 	result := &models.CornerStore{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/corner-store/%s", id)
-	err := s.client.DoRequest(http.MethodGet, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodGet, path, nil, result,
+		client.WithOperation("cornerstores", "Get"), client.WithAttribute(client.AttributeCornerStoreID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error getting corner store %s: %w", id, err)
 	}
@@ -47,12 +87,22 @@ func (s *Service) Get(id string) (*models.CornerStore, error) {
 	return result, nil
 }
 
-// Create creates a new corner store
+// Create validates params and creates a new corner store
 func (s *Service) Create(params *models.CornerStoreCreateParams) (*models.CornerStore, error) {
+	return s.CreateContext(context.Background(), params)
+}
+
+// CreateContext is like Create but honors ctx for cancellation and deadlines
+func (s *Service) CreateContext(ctx context.Context, params *models.CornerStoreCreateParams) (*models.CornerStore, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error creating corner store: %w", err)
+	}
+
 	result := &models.CornerStore{}
 
 	// Endpoint placeholder - should be updated when documentation is available
-	err := s.client.DoRequest(http.MethodPost, "/v1/corner-store", params, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, "/v1/corner-store", params, result,
+		client.WithOperation("cornerstores", "Create"))
 	if err != nil {
 		return nil, fmt.Errorf("error creating corner store: %w", err)
 	}
@@ -60,13 +110,23 @@ func (s *Service) Create(params *models.CornerStoreCreateParams) (*models.Corner
 	return result, nil
 }
 
-// Update updates an existing corner store
+// Update validates params and updates an existing corner store
 func (s *Service) Update(id string, params *models.CornerStoreUpdateParams) (*models.CornerStore, error) {
+	return s.UpdateContext(context.Background(), id, params)
+}
+
+// UpdateContext is like Update but honors ctx for cancellation and deadlines
+func (s *Service) UpdateContext(ctx context.Context, id string, params *models.CornerStoreUpdateParams) (*models.CornerStore, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error updating corner store %s: %w", id, err)
+	}
+
 	result := &models.CornerStore{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/corner-store/%s", id)
-	err := s.client.DoRequest(http.MethodPut, path, params, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPut, path, params, result,
+		client.WithOperation("cornerstores", "Update"), client.WithAttribute(client.AttributeCornerStoreID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error updating corner store %s: %w", id, err)
 	}
@@ -76,9 +136,15 @@ func (s *Service) Update(id string, params *models.CornerStoreUpdateParams) (*mo
 
 // Delete deletes a corner store
 func (s *Service) Delete(id string) error {
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is like Delete but honors ctx for cancellation and deadlines
+func (s *Service) DeleteContext(ctx context.Context, id string) error {
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/corner-store/%s", id)
-	err := s.client.DoRequest(http.MethodDelete, path, nil, nil)
+	err := s.client.DoRequestContext(ctx, http.MethodDelete, path, nil, nil,
+		client.WithOperation("cornerstores", "Delete"), client.WithAttribute(client.AttributeCornerStoreID, id))
 	if err != nil {
 		return fmt.Errorf("error deleting corner store %s: %w", id, err)
 	}
@@ -86,11 +152,18 @@ func (s *Service) Delete(id string) error {
 	return nil
 }
 
+// GetCornerStoreInfoByExternalId retrieves the credit info of a corner store by its external ID
 func (s *Service) GetCornerStoreInfoByExternalId(id int) (*models.CornerStoreInfo, error) {
+	return s.GetCornerStoreInfoByExternalIdContext(context.Background(), id)
+}
+
+// GetCornerStoreInfoByExternalIdContext is like GetCornerStoreInfoByExternalId but honors ctx for cancellation and deadlines
+func (s *Service) GetCornerStoreInfoByExternalIdContext(ctx context.Context, id int) (*models.CornerStoreInfo, error) {
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/corner-store/external/%d", id)
 	result := &models.CornerStoreInfo{}
-	err := s.client.DoRequest(http.MethodGet, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodGet, path, nil, result,
+		client.WithOperation("cornerstores", "GetCornerStoreInfoByExternalId"), client.WithAttribute(client.AttributeCornerStoreExternalID, strconv.Itoa(id)))
 	if err != nil {
 		return nil, fmt.Errorf("error getting corner store info by external ID %d: %w", id, err)
 	}
@@ -0,0 +1,123 @@
+// Package batch creates transactions concurrently, for transactions.Service.CreateBatch
+// and the fakes of propagamock
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// DefaultConcurrency is the number of transactions created at once when none is configured
+const DefaultConcurrency = 4
+
+// Options configures Run
+type Options struct {
+	// Concurrency is the number of transactions created at once, DefaultConcurrency when zero
+	Concurrency int
+}
+
+// ItemResult is the outcome of the creation of a transaction of a batch
+type ItemResult struct {
+	// Index is the position of the item in the batch
+	Index int
+
+	// Params are the parameters the transaction was created with
+	Params *models.TransactionCreateParams
+
+	// Transaction is the created transaction, nil when Err is set
+	Transaction *models.Transaction
+
+	// Err is the error returned by the creation, such as a *client.Error or a *models.ValidationError
+	Err error
+}
+
+// Result is the outcome of Run
+type Result struct {
+	// Items holds a result per item, in the order of the batch
+	Items []ItemResult
+
+	// Succeeded and Failed count the items created and the items that failed
+	Succeeded int
+	Failed    int
+}
+
+// Failures returns the results of the items that failed
+func (r *Result) Failures() []ItemResult {
+	var failures []ItemResult
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failures = append(failures, item)
+		}
+	}
+	return failures
+}
+
+// Transactions returns the created transactions, in the order of the batch
+func (r *Result) Transactions() []*models.Transaction {
+	var created []*models.Transaction
+	for _, item := range r.Items {
+		if item.Err == nil {
+			created = append(created, item.Transaction)
+		}
+	}
+	return created
+}
+
+// CreateFunc creates a single transaction, such as transactions.Service.CreateContext
+type CreateFunc func(ctx context.Context, params *models.TransactionCreateParams) (*models.Transaction, error)
+
+// Run creates every transaction of params with create using a pool of workers.
+// The error is only set when ctx is done before every item was processed, the items left
+// then fail with client.ErrRequestCanceled.
+func Run(ctx context.Context, create CreateFunc, params []*models.TransactionCreateParams, opts *Options) (*Result, error) {
+	concurrency := DefaultConcurrency
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+	concurrency = max(min(concurrency, len(params)), 1)
+
+	result := &Result{Items: make([]ItemResult, len(params))}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				item := &result.Items[i]
+				item.Transaction, item.Err = create(ctx, params[i])
+			}
+		}()
+	}
+
+	var err error
+	for i, p := range params {
+		result.Items[i] = ItemResult{Index: i, Params: p}
+		if err == nil {
+			select {
+			case indexes <- i:
+				continue
+			case <-ctx.Done():
+				err = fmt.Errorf("error creating transaction batch: %w: %w", client.ErrRequestCanceled, ctx.Err())
+			}
+		}
+		result.Items[i].Err = err
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, item := range result.Items {
+		if item.Err != nil {
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+	}
+
+	return result, err
+}
@@ -0,0 +1,167 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// batchParams returns n parameters whose WholesalerTransactionId is their index
+func batchParams(n int) []*models.TransactionCreateParams {
+	params := make([]*models.TransactionCreateParams, n)
+	for i := range params {
+		params[i] = &models.TransactionCreateParams{WholesalerTransactionId: fmt.Sprint(i)}
+	}
+	return params
+}
+
+// echo creates a transaction whose ID is the WholesalerTransactionId of params
+func echo(ctx context.Context, params *models.TransactionCreateParams) (*models.Transaction, error) {
+	return &models.Transaction{TransactionId: params.WholesalerTransactionId}, nil
+}
+
+func TestRunKeepsOrder(t *testing.T) {
+	params := batchParams(50)
+
+	// Later items finish first
+	create := func(ctx context.Context, p *models.TransactionCreateParams) (*models.Transaction, error) {
+		var i int
+		fmt.Sscan(p.WholesalerTransactionId, &i)
+		time.Sleep(time.Duration(len(params)-i) * 100 * time.Microsecond)
+		return echo(ctx, p)
+	}
+
+	result, err := Run(context.Background(), create, params, &Options{Concurrency: 8})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	for i, item := range result.Items {
+		if item.Index != i || item.Params != params[i] || item.Transaction.TransactionId != fmt.Sprint(i) {
+			t.Errorf("Items[%d] = %+v, want the result of item %d", i, item, i)
+		}
+	}
+}
+
+func TestRunConcurrency(t *testing.T) {
+	tests := []struct {
+		name  string
+		items int
+		opts  *Options
+		want  int32
+	}{
+		{name: "configured", items: 20, opts: &Options{Concurrency: 3}, want: 3},
+		{name: "default", items: 20, opts: nil, want: DefaultConcurrency},
+		{name: "zero", items: 20, opts: &Options{}, want: DefaultConcurrency},
+		{name: "fewer items", items: 2, opts: &Options{Concurrency: 10}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var active, peak atomic.Int32
+			create := func(ctx context.Context, p *models.TransactionCreateParams) (*models.Transaction, error) {
+				n := active.Add(1)
+				defer active.Add(-1)
+				for {
+					old := peak.Load()
+					if n <= old || peak.CompareAndSwap(old, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				return echo(ctx, p)
+			}
+
+			if _, err := Run(context.Background(), create, batchParams(tt.items), tt.opts); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if got := peak.Load(); got != tt.want {
+				t.Errorf("peak concurrency = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunCanceledMidway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first creation cancels the batch and holds the only worker, so the
+	// items left are never handed out
+	var calls atomic.Int32
+	create := func(ctx context.Context, p *models.TransactionCreateParams) (*models.Transaction, error) {
+		if calls.Add(1) == 1 {
+			cancel()
+			time.Sleep(50 * time.Millisecond)
+		}
+		return echo(ctx, p)
+	}
+
+	result, err := Run(ctx, create, batchParams(5), &Options{Concurrency: 1})
+	if !errors.Is(err, client.ErrRequestCanceled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want ErrRequestCanceled wrapping context.Canceled", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("create called %d times, want 1", calls.Load())
+	}
+	if first := result.Items[0]; first.Err != nil || first.Transaction == nil {
+		t.Errorf("Items[0] = %+v, want the transaction created before the cancellation", first)
+	}
+	for _, item := range result.Items[1:] {
+		if !errors.Is(item.Err, client.ErrRequestCanceled) || item.Transaction != nil || item.Params == nil {
+			t.Errorf("Items[%d] = %+v, want a canceled item", item.Index, item)
+		}
+	}
+	if result.Succeeded != 1 || result.Failed != 4 {
+		t.Errorf("Succeeded, Failed = %d, %d, want 1, 4", result.Succeeded, result.Failed)
+	}
+}
+
+func TestRunSummary(t *testing.T) {
+	errRejected := errors.New("rejected")
+	create := func(ctx context.Context, p *models.TransactionCreateParams) (*models.Transaction, error) {
+		if p.WholesalerTransactionId == "1" || p.WholesalerTransactionId == "3" {
+			return nil, errRejected
+		}
+		return echo(ctx, p)
+	}
+
+	result, err := Run(context.Background(), create, batchParams(5), nil)
+	if err != nil {
+		t.Fatalf("Run() error = %v, want nil when items fail on their own", err)
+	}
+
+	if result.Succeeded != 3 || result.Failed != 2 {
+		t.Errorf("Succeeded, Failed = %d, %d, want 3, 2", result.Succeeded, result.Failed)
+	}
+
+	failures := result.Failures()
+	if len(failures) != 2 || failures[0].Index != 1 || failures[1].Index != 3 || !errors.Is(failures[0].Err, errRejected) {
+		t.Errorf("Failures() = %+v, want items 1 and 3", failures)
+	}
+
+	var ids []string
+	for _, tx := range result.Transactions() {
+		ids = append(ids, tx.TransactionId)
+	}
+	if fmt.Sprint(ids) != "[0 2 4]" {
+		t.Errorf("Transactions() = %v, want the transactions of items 0, 2 and 4", ids)
+	}
+}
+
+func TestRunEmpty(t *testing.T) {
+	result, err := Run(context.Background(), echo, nil, nil)
+	if err != nil || len(result.Items) != 0 || result.Succeeded != 0 || result.Failed != 0 {
+		t.Errorf("Run(nil) = %+v, %v, want an empty result", result, err)
+	}
+	if result.Failures() != nil || result.Transactions() != nil {
+		t.Errorf("Failures(), Transactions() of an empty result = %v, %v, want nil", result.Failures(), result.Transactions())
+	}
+}
@@ -0,0 +1,143 @@
+// Command extragen generates the JSON methods of the models keeping unknown fields in Extra.
+//
+// It is run by go generate in the models package and writes extra_gen.go, with an
+// UnmarshalJSON and a MarshalJSON method for every struct holding an Extra field:
+//
+//	//go:generate go run ../internal/extragen
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// output is the name of the generated file
+const output = "extra_gen.go"
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the models package")
+	flag.Parse()
+
+	src, err := generate(*dir)
+	if err != nil {
+		log.Fatalf("extragen: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, output), src, 0o644); err != nil {
+		log.Fatalf("extragen: %v", err)
+	}
+}
+
+// generate returns the source of the JSON methods of the models of the package in dir
+func generate(dir string) ([]byte, error) {
+	pkg, types, err := modelsWithExtra(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := methods.Execute(&buf, struct {
+		Package string
+		Types   []string
+	}{pkg, types}); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting the generated code: %w", err)
+	}
+	return src, nil
+}
+
+// modelsWithExtra returns the name of the package in dir and its struct types with an
+// Extra field of type Extra, in the order of their declaration
+func modelsWithExtra(dir string) (string, []string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != output
+	}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(pkgs) != 1 {
+		return "", nil, fmt.Errorf("found %d packages in %s, want 1", len(pkgs), dir)
+	}
+
+	var name string
+	var files []*ast.File
+	for pkgName, pkg := range pkgs {
+		name = pkgName
+		for _, file := range pkg.Files {
+			files = append(files, file)
+		}
+	}
+	slices.SortFunc(files, func(a, b *ast.File) int {
+		return strings.Compare(fset.File(a.Pos()).Name(), fset.File(b.Pos()).Name())
+	})
+
+	var types []string
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok || spec.TypeParams != nil {
+				return true
+			}
+			if s, ok := spec.Type.(*ast.StructType); ok && hasExtra(s) {
+				types = append(types, spec.Name.Name)
+			}
+			return true
+		})
+	}
+	return name, types, nil
+}
+
+// hasExtra reports whether s has a field named Extra of type Extra
+func hasExtra(s *ast.StructType) bool {
+	for _, field := range s.Fields.List {
+		ident, ok := field.Type.(*ast.Ident)
+		if !ok || ident.Name != "Extra" {
+			continue
+		}
+		for _, name := range field.Names {
+			if name.Name == "Extra" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// methods is the template of the generated file
+var methods = template.Must(template.New(output).Parse(`// Code generated by extragen. DO NOT EDIT.
+
+package {{.Package}}
+{{range .Types}}
+// UnmarshalJSON implements json.Unmarshaler, keeping the unknown fields in Extra
+func (m *{{.}}) UnmarshalJSON(data []byte) error {
+	type plain {{.}}
+	extra, err := unmarshalExtra(data, (*plain)(m))
+	if err != nil {
+		return err
+	}
+	m.Extra = extra
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, emitting the fields of Extra after the known ones
+func (m {{.}}) MarshalJSON() ([]byte, error) {
+	type plain {{.}}
+	return marshalExtra(plain(m), m.Extra)
+}
+{{end}}`))
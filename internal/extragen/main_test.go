@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedFileUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "models")
+
+	want, err := generate(dir)
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, output))
+	if err != nil {
+		t.Fatalf("error reading %s: %v", output, err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("models/%s is out of date, run go generate ./models", output)
+	}
+}
+
+func TestModelsWithExtra(t *testing.T) {
+	pkg, types, err := modelsWithExtra(filepath.Join("..", "..", "models"))
+	if err != nil {
+		t.Fatalf("modelsWithExtra() error = %v", err)
+	}
+
+	if pkg != "models" {
+		t.Errorf("package = %q, want models", pkg)
+	}
+	want := map[string]bool{"Transaction": true, "Product": true, "CornerStoreInfo": true, "AccountListResponse": true}
+	for _, name := range types {
+		delete(want, name)
+		if name == "TransactionCreateParams" || name == "Extra" {
+			t.Errorf("%s has no Extra field", name)
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("missing models %v", want)
+	}
+}
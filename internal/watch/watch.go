@@ -0,0 +1,173 @@
+// Package watch polls transactions for status changes, for transactions.Service.Watch
+// and the fakes of propagamock
+package watch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// Default values used by Run
+const (
+	DefaultInterval    = 5 * time.Second
+	DefaultMaxInterval = time.Minute
+)
+
+// Options configures Run
+type Options struct {
+	// Interval is the delay between polls, DefaultInterval when zero.
+	// It doubles while the status does not change and resets on every change.
+	Interval time.Duration
+
+	// MaxInterval caps the delay between polls, DefaultMaxInterval when zero
+	MaxInterval time.Duration
+}
+
+// StatusChangeEvent reports a change of the status of a watched transaction
+type StatusChangeEvent struct {
+	// TransactionID is the ID of the watched transaction
+	TransactionID string
+
+	// OldStatus is the status previously seen, empty for the first event of a transaction
+	OldStatus models.TransactionStatus
+
+	// NewStatus is the current status of the transaction
+	NewStatus models.TransactionStatus
+
+	// Transaction is the transaction as last retrieved, nil when Err is set
+	Transaction *models.Transaction
+
+	// Err is set when the transaction cannot be watched anymore, e.g. because it does not exist
+	Err error
+}
+
+// GetFunc retrieves a single transaction, such as transactions.Service.GetContext
+type GetFunc func(ctx context.Context, id string) (*models.Transaction, error)
+
+// Run polls the transactions ids with get and emits an event each time the status of one
+// of them changes, the first event of each carrying the status found by the first poll.
+// A transaction stops being watched once it reaches a terminal status or cannot be retrieved
+// anymore, and the channel is closed once none is watched or when ctx is done.
+// Transient errors, network errors and API errors of status 429 or 5xx, are retried with the
+// polling backoff, any other error ends the watch of the transaction with an event carrying it.
+func Run(ctx context.Context, get GetFunc, ids []string, opts *Options) <-chan StatusChangeEvent {
+	interval, maxInterval := DefaultInterval, DefaultMaxInterval
+	if opts != nil {
+		if opts.Interval > 0 {
+			interval = opts.Interval
+		}
+		if opts.MaxInterval > 0 {
+			maxInterval = opts.MaxInterval
+		}
+	}
+	maxInterval = max(maxInterval, interval)
+
+	events := make(chan StatusChangeEvent)
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			poll(ctx, get, id, interval, maxInterval, events)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events
+}
+
+// poll polls a single transaction until it reaches a terminal status
+func poll(ctx context.Context, get GetFunc, id string, interval, maxInterval time.Duration, events chan<- StatusChangeEvent) {
+	var status models.TransactionStatus
+	delay := interval
+	for first := true; ; first = false {
+		if !first && !sleep(ctx, delay) {
+			return
+		}
+
+		transaction, err := get(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if permanentError(err) {
+				emit(ctx, events, StatusChangeEvent{TransactionID: id, OldStatus: status, NewStatus: status, Err: err})
+				return
+			}
+			delay = min(delay*2, maxInterval)
+			continue
+		}
+
+		if !first && transaction.TransactionStatus == status {
+			delay = min(delay*2, maxInterval)
+			continue
+		}
+
+		event := StatusChangeEvent{
+			TransactionID: id,
+			OldStatus:     status,
+			NewStatus:     transaction.TransactionStatus,
+			Transaction:   transaction,
+		}
+		if !emit(ctx, events, event) || transaction.TransactionStatus.IsTerminal() {
+			return
+		}
+		status = transaction.TransactionStatus
+		delay = interval
+	}
+}
+
+// sleep waits for d and reports whether it elapsed before ctx was done, replaced by the tests
+var sleep = func(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// emit sends event unless ctx is done first
+func emit(ctx context.Context, events chan<- StatusChangeEvent, event StatusChangeEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// permanentError reports whether polling again cannot succeed. Only network errors and
+// API errors of status 429 or 5xx are transient, anything else such as a 4xx, a response
+// failing strict decoding or an invalid transaction would fail again on every poll.
+func permanentError(err error) bool {
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode != http.StatusTooManyRequests && apiErr.StatusCode < 500
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return false
+	}
+	return true
+}
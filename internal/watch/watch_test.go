@@ -0,0 +1,264 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// step is a scripted answer of the fake GetFunc, a status or an error
+type step struct {
+	status models.TransactionStatus
+	err    error
+}
+
+// script returns a GetFunc answering each call with the next step, the last one repeating,
+// along with a function returning the number of calls
+func script(steps ...step) (GetFunc, func() int) {
+	var mu sync.Mutex
+	calls := 0
+	get := func(ctx context.Context, id string) (*models.Transaction, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		s := steps[min(calls, len(steps)-1)]
+		calls++
+		if s.err != nil {
+			return nil, s.err
+		}
+		return &models.Transaction{TransactionId: id, TransactionStatus: s.status}, nil
+	}
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+	return get, count
+}
+
+// fakeSleep replaces sleep for the test with one returning at once and records the delays
+func fakeSleep(t *testing.T) func() []time.Duration {
+	var mu sync.Mutex
+	var delays []time.Duration
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		delays = append(delays, d)
+		return ctx.Err() == nil
+	}
+	t.Cleanup(func() { sleep = original })
+	return func() []time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(delays)
+	}
+}
+
+// collect reads events until the channel is closed
+func collect(t *testing.T, events <-chan StatusChangeEvent) []StatusChangeEvent {
+	t.Helper()
+	var got []StatusChangeEvent
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return got
+			}
+			got = append(got, event)
+		case <-timeout:
+			t.Fatalf("channel not closed, events so far %+v", got)
+		}
+	}
+}
+
+func TestRunDeduplicatesStatuses(t *testing.T) {
+	fakeSleep(t)
+	get, calls := script(
+		step{status: models.TransactionStatusPending},
+		step{status: models.TransactionStatusPending},
+		step{status: models.TransactionStatusOnHold},
+		step{status: models.TransactionStatusOnHold},
+		step{status: models.TransactionStatusDelivery},
+		step{status: models.TransactionStatusPaid},
+	)
+
+	got := collect(t, Run(context.Background(), get, []string{"txn_1"}, nil))
+
+	want := [][2]models.TransactionStatus{
+		{"", models.TransactionStatusPending},
+		{models.TransactionStatusPending, models.TransactionStatusOnHold},
+		{models.TransactionStatusOnHold, models.TransactionStatusDelivery},
+		{models.TransactionStatusDelivery, models.TransactionStatusPaid},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d events %+v, want %d", len(got), got, len(want))
+	}
+	for i, event := range got {
+		if event.OldStatus != want[i][0] || event.NewStatus != want[i][1] || event.Err != nil ||
+			event.TransactionID != "txn_1" || event.Transaction.TransactionStatus != event.NewStatus {
+			t.Errorf("event %d = %+v, want %s -> %s", i, event, want[i][0], want[i][1])
+		}
+	}
+	if calls() != 6 {
+		t.Errorf("get called %d times, want polling to stop on the terminal status", calls())
+	}
+}
+
+func TestRunStopsOnTerminalStatus(t *testing.T) {
+	fakeSleep(t)
+
+	for _, status := range []models.TransactionStatus{models.TransactionStatusCancelled, models.TransactionStatusExpired, models.TransactionStatusPaid} {
+		t.Run(string(status), func(t *testing.T) {
+			get, calls := script(step{status: status})
+
+			got := collect(t, Run(context.Background(), get, []string{"txn_1"}, nil))
+
+			if len(got) != 1 || got[0].NewStatus != status || calls() != 1 {
+				t.Errorf("events = %+v after %d calls, want a single event", got, calls())
+			}
+		})
+	}
+}
+
+func TestRunBackoff(t *testing.T) {
+	delays := fakeSleep(t)
+	get, _ := script(
+		step{status: models.TransactionStatusPending},
+		step{status: models.TransactionStatusPending},
+		step{status: models.TransactionStatusPending},
+		step{err: &client.Error{StatusCode: http.StatusServiceUnavailable}},
+		step{status: models.TransactionStatusPending},
+		step{status: models.TransactionStatusOnHold},
+		step{status: models.TransactionStatusOnHold},
+		step{status: models.TransactionStatusCancelled},
+	)
+
+	got := collect(t, Run(context.Background(), get, []string{"txn_1"}, &Options{Interval: 10 * time.Millisecond, MaxInterval: 40 * time.Millisecond}))
+
+	// The delay doubles up to MaxInterval while nothing changes, errors included, and resets on a change
+	ms := time.Millisecond
+	want := []time.Duration{10 * ms, 20 * ms, 40 * ms, 40 * ms, 40 * ms, 10 * ms, 20 * ms}
+	if !slices.Equal(delays(), want) {
+		t.Errorf("delays = %v, want %v", delays(), want)
+	}
+	if len(got) != 3 {
+		t.Errorf("events = %+v, want the transient error not reported", got)
+	}
+}
+
+func TestRunDefaultIntervals(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *Options
+		want []time.Duration
+	}{
+		{name: "nil options", opts: nil, want: []time.Duration{DefaultInterval, 2 * DefaultInterval}},
+		{name: "interval above the default maximum", opts: &Options{Interval: 2 * time.Minute}, want: []time.Duration{2 * time.Minute, 2 * time.Minute}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delays := fakeSleep(t)
+			get, _ := script(
+				step{status: models.TransactionStatusPending},
+				step{status: models.TransactionStatusPending},
+				step{status: models.TransactionStatusPaid},
+			)
+
+			collect(t, Run(context.Background(), get, []string{"txn_1"}, tt.opts))
+
+			if !slices.Equal(delays(), tt.want) {
+				t.Errorf("delays = %v, want %v", delays(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunStopsOnPermanentError(t *testing.T) {
+	fakeSleep(t)
+	notFound := &client.Error{StatusCode: http.StatusNotFound}
+	get, calls := script(
+		step{status: models.TransactionStatusOnHold},
+		step{err: fmt.Errorf("error getting transaction: %w", notFound)},
+	)
+
+	got := collect(t, Run(context.Background(), get, []string{"txn_1"}, nil))
+
+	if len(got) != 2 || calls() != 2 {
+		t.Fatalf("events = %+v after %d calls, want the status and the error", got, calls())
+	}
+	last := got[1]
+	if !errors.Is(last.Err, notFound) || last.Transaction != nil ||
+		last.OldStatus != models.TransactionStatusOnHold || last.NewStatus != models.TransactionStatusOnHold {
+		t.Errorf("error event = %+v, want the 404 with the last status seen", last)
+	}
+}
+
+func TestPermanentError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "not found", err: &client.Error{StatusCode: http.StatusNotFound}, want: true},
+		{name: "unauthorized", err: &client.Error{StatusCode: http.StatusUnauthorized}, want: true},
+		{name: "rate limited", err: &client.Error{StatusCode: http.StatusTooManyRequests}, want: false},
+		{name: "server error", err: fmt.Errorf("error getting transaction: %w", &client.Error{StatusCode: http.StatusBadGateway}), want: false},
+		{name: "network error", err: fmt.Errorf("error performing HTTP request: %w", &url.Error{Op: "Get", URL: "/", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}), want: false},
+		{name: "truncated body", err: fmt.Errorf("error reading response body: %w", io.ErrUnexpectedEOF), want: false},
+		{name: "strict decoding", err: fmt.Errorf("error deserializing response: %w", errors.New(`json: unknown field "extra"`)), want: true},
+		{name: "validation", err: &models.ValidationError{Errors: []models.FieldError{{Field: "status", Rule: models.RuleRequired}}}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := permanentError(tt.err); got != tt.want {
+				t.Errorf("permanentError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunMany(t *testing.T) {
+	fakeSleep(t)
+	get, calls := script(step{status: models.TransactionStatusPaid})
+
+	got := collect(t, Run(context.Background(), get, []string{"txn_1", "txn_2", "txn_1"}, nil))
+
+	var ids []string
+	for _, event := range got {
+		ids = append(ids, event.TransactionID)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"txn_1", "txn_2"}) || calls() != 2 {
+		t.Errorf("events for %v after %d calls, want one per distinct ID", ids, calls())
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	fakeSleep(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	get, _ := script(step{status: models.TransactionStatusPending})
+
+	events := Run(ctx, get, []string{"txn_1"}, nil)
+	if first := <-events; first.NewStatus != models.TransactionStatusPending {
+		t.Fatalf("first event = %+v", first)
+	}
+	cancel()
+
+	if got := collect(t, events); len(got) != 0 {
+		t.Errorf("events after cancel = %+v, want the channel closed", got)
+	}
+}
@@ -1,7 +1,9 @@
 package kyc
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/diogenes-moreira/propaga-sdk/client"
@@ -22,10 +24,21 @@ func NewService(client *client.Client) *Service {
 
 // List retrieves a list of KYC verifications based on the specified parameters
 func (s *Service) List(params *models.KYCListParams) (*models.KYCListResponse, error) {
+	return s.ListContext(context.Background(), params)
+}
+
+// ListContext is like List but honors ctx for cancellation and deadlines
+func (s *Service) ListContext(ctx context.Context, params *models.KYCListParams) (*models.KYCListResponse, error) {
 	result := &models.KYCListResponse{}
 
 	// Endpoint placeholder - should be updated when documentation is available
-	err := s.client.DoRequest(http.MethodGet, "/v1/kyc", params, result)
+	query, err := client.EncodeQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("error listing KYC verifications: %w", err)
+	}
+
+	err = s.client.DoRequestContext(ctx, http.MethodGet, "/v1/kyc", nil, result,
+		client.WithQuery(query), client.WithOperation("kyc", "List"))
 	if err != nil {
 		return nil, fmt.Errorf("error listing KYC verifications: %w", err)
 	}
@@ -33,13 +46,39 @@ func (s *Service) List(params *models.KYCListParams) (*models.KYCListResponse, e
 	return result, nil
 }
 
+// ListAll returns a sequence over every KYC verification matching params, fetching pages lazily.
+// params.Offset is used as the starting point and params.Limit as page size unless opts sets one.
+func (s *Service) ListAll(ctx context.Context, params *models.KYCListParams, opts *client.IteratorOptions) iter.Seq2[models.KYC, error] {
+	return s.ListIterator(ctx, params, opts).All()
+}
+
+// ListIterator returns an iterator over every KYC verification matching params, fetching pages lazily
+func (s *Service) ListIterator(ctx context.Context, params *models.KYCListParams, opts *client.IteratorOptions) *client.Iterator[models.KYC] {
+	pageFields := func(p *models.KYCListParams) (*int, *int) { return &p.Limit, &p.Offset }
+	list := func(ctx context.Context, page *models.KYCListParams) ([]models.KYC, int, error) {
+		result, err := s.ListContext(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data, result.TotalCount, nil
+	}
+
+	return client.ListIterator(ctx, params, pageFields, list, opts)
+}
+
 // Get retrieves a specific KYC verification by its ID
 func (s *Service) Get(id string) (*models.KYC, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but honors ctx for cancellation and deadlines
+func (s *Service) GetContext(ctx context.Context, id string) (*models.KYC, error) {
 	result := &models.KYC{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/kyc/%s", id)
-	err := s.client.DoRequest(http.MethodGet, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodGet, path, nil, result,
+		client.WithOperation("kyc", "Get"), client.WithAttribute(client.AttributeKYCID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error getting KYC verification %s: %w", id, err)
 	}
@@ -47,12 +86,22 @@ func (s *Service) Get(id string) (*models.KYC, error) {
 	return result, nil
 }
 
-// Create creates a new KYC verification
+// Create validates params and creates a new KYC verification
 func (s *Service) Create(params *models.KYCCreateParams) (*models.KYC, error) {
+	return s.CreateContext(context.Background(), params)
+}
+
+// CreateContext is like Create but honors ctx for cancellation and deadlines
+func (s *Service) CreateContext(ctx context.Context, params *models.KYCCreateParams) (*models.KYC, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error creating KYC verification: %w", err)
+	}
+
 	result := &models.KYC{}
 
 	// Endpoint placeholder - should be updated when documentation is available
-	err := s.client.DoRequest(http.MethodPost, "/v1/kyc", params, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, "/v1/kyc", params, result,
+		client.WithOperation("kyc", "Create"))
 	if err != nil {
 		return nil, fmt.Errorf("error creating KYC verification: %w", err)
 	}
@@ -60,13 +109,23 @@ func (s *Service) Create(params *models.KYCCreateParams) (*models.KYC, error) {
 	return result, nil
 }
 
-// Update updates an existing KYC verification
+// Update validates params and updates an existing KYC verification
 func (s *Service) Update(id string, params *models.KYCUpdateParams) (*models.KYC, error) {
+	return s.UpdateContext(context.Background(), id, params)
+}
+
+// UpdateContext is like Update but honors ctx for cancellation and deadlines
+func (s *Service) UpdateContext(ctx context.Context, id string, params *models.KYCUpdateParams) (*models.KYC, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error updating KYC verification %s: %w", id, err)
+	}
+
 	result := &models.KYC{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/kyc/%s", id)
-	err := s.client.DoRequest(http.MethodPut, path, params, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPut, path, params, result,
+		client.WithOperation("kyc", "Update"), client.WithAttribute(client.AttributeKYCID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error updating KYC verification %s: %w", id, err)
 	}
@@ -76,11 +135,17 @@ func (s *Service) Update(id string, params *models.KYCUpdateParams) (*models.KYC
 
 // Verify marks a KYC verification as verified
 func (s *Service) Verify(id string) (*models.KYC, error) {
+	return s.VerifyContext(context.Background(), id)
+}
+
+// VerifyContext is like Verify but honors ctx for cancellation and deadlines
+func (s *Service) VerifyContext(ctx context.Context, id string) (*models.KYC, error) {
 	result := &models.KYC{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/kyc/%s/verify", id)
-	err := s.client.DoRequest(http.MethodPost, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, path, nil, result,
+		client.WithOperation("kyc", "Verify"), client.WithAttribute(client.AttributeKYCID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error verifying KYC %s: %w", id, err)
 	}
@@ -90,12 +155,18 @@ func (s *Service) Verify(id string) (*models.KYC, error) {
 
 // Reject rejects a KYC verification with a reason
 func (s *Service) Reject(id string, reason string) (*models.KYC, error) {
+	return s.RejectContext(context.Background(), id, reason)
+}
+
+// RejectContext is like Reject but honors ctx for cancellation and deadlines
+func (s *Service) RejectContext(ctx context.Context, id string, reason string) (*models.KYC, error) {
 	result := &models.KYC{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/kyc/%s/reject", id)
 	payload := map[string]string{"reason": reason}
-	err := s.client.DoRequest(http.MethodPost, path, payload, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, path, payload, result,
+		client.WithOperation("kyc", "Reject"), client.WithAttribute(client.AttributeKYCID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error rejecting KYC %s: %w", id, err)
 	}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the layout used to serialize a Date
+const DateLayout = "2006-01-02"
+
+// timestampLayouts are the layouts accepted when parsing dates and timestamps, in order
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	DateLayout,
+}
+
+// Date is a calendar date, such as a delivery date or a date of birth.
+// It is serialized as YYYY-MM-DD and accepts RFC 3339 timestamps, YYYY-MM-DD,
+// empty strings and null, the last two leaving it zero.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of the given year, month and day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the date of t in its location
+func DateOf(t time.Time) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+	return NewDate(t.Date())
+}
+
+// ParseDate parses a date in any of the formats accepted by Date, an empty string giving the zero date
+func ParseDate(s string) (Date, error) {
+	t, err := parseTimestamp(s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q: must be YYYY-MM-DD or RFC 3339", s)
+	}
+	return DateOf(t), nil
+}
+
+// String returns the date formatted as YYYY-MM-DD, empty for the zero date
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(DateLayout)
+}
+
+// MarshalText implements encoding.TextMarshaler, used for query parameters
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON encodes the date as a YYYY-MM-DD string, null for the zero date.
+// Like Timestamp, it no longer sends "" when empty.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a date from a JSON string or null
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s, err := unquoteTime(data)
+	if err != nil {
+		return fmt.Errorf("invalid date %s: %w", data, err)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// Timestamp is an instant, such as the creation time of a resource.
+// It is serialized in RFC 3339 format and accepts RFC 3339 timestamps, timestamps
+// without time zone (taken as UTC), YYYY-MM-DD, empty strings and null, the last two leaving it zero.
+type Timestamp struct {
+	time.Time
+}
+
+// TimestampOf returns the timestamp of t
+func TimestampOf(t time.Time) Timestamp {
+	return Timestamp{t}
+}
+
+// ParseTimestamp parses a timestamp in any of the formats accepted by Timestamp,
+// an empty string giving the zero timestamp
+func ParseTimestamp(s string) (Timestamp, error) {
+	t, err := parseTimestamp(s)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q: must be RFC 3339 or YYYY-MM-DD", s)
+	}
+	return Timestamp{t}, nil
+}
+
+// String returns the timestamp in RFC 3339 format, empty for the zero timestamp
+func (ts Timestamp) String() string {
+	if ts.IsZero() {
+		return ""
+	}
+	return ts.Format(time.RFC3339Nano)
+}
+
+// MarshalText implements encoding.TextMarshaler, used for query parameters
+func (ts Timestamp) MarshalText() ([]byte, error) {
+	return []byte(ts.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (ts *Timestamp) UnmarshalText(text []byte) error {
+	parsed, err := ParseTimestamp(string(text))
+	if err != nil {
+		return err
+	}
+	*ts = parsed
+	return nil
+}
+
+// MarshalJSON encodes the timestamp as a RFC 3339 string, null for the zero timestamp.
+// This is a wire change from the string fields Timestamp replaced, which sent "" when empty;
+// fields that must be left out when zero are tagged omitzero instead.
+func (ts Timestamp) MarshalJSON() ([]byte, error) {
+	if ts.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + ts.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a timestamp from a JSON string or null
+func (ts *Timestamp) UnmarshalJSON(data []byte) error {
+	s, err := unquoteTime(data)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %s: %w", data, err)
+	}
+	return ts.UnmarshalText([]byte(s))
+}
+
+// parseTimestamp parses s with the first matching layout of timestampLayouts
+func parseTimestamp(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	var err error
+	for _, layout := range timestampLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// unquoteTime returns the content of a JSON string, empty for null
+func unquoteTime(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return "", fmt.Errorf("must be a string")
+	}
+	return string(data[1 : len(data)-1]), nil
+}
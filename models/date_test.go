@@ -0,0 +1,201 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	mexico := time.FixedZone("CST", -6*60*60)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2025-01-02T15:04:05Z", want: time.Date(2025, time.January, 2, 15, 4, 5, 0, time.UTC)},
+		{value: "2025-01-02T15:04:05.123456789-06:00", want: time.Date(2025, time.January, 2, 15, 4, 5, 123456789, mexico)},
+		{value: "2025-01-02T15:04:05", want: time.Date(2025, time.January, 2, 15, 4, 5, 0, time.UTC)},
+		{value: "2025-01-02 15:04:05", want: time.Date(2025, time.January, 2, 15, 4, 5, 0, time.UTC)},
+		{value: "2025-01-02", want: time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{value: "", want: time.Time{}},
+		{value: "02/01/2025", wantErr: true},
+		{value: "2025-13-01", wantErr: true},
+		{value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTimestamp(%q) = %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("ParseTimestamp(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Date
+		wantErr bool
+	}{
+		{value: "2025-01-02", want: NewDate(2025, time.January, 2)},
+		{value: "2025-01-02 23:30:00", want: NewDate(2025, time.January, 2)},
+		// The date is the one of the timestamp in its own zone, not in UTC
+		{value: "2025-01-02T23:30:00-06:00", want: NewDate(2025, time.January, 2)},
+		{value: "2025-01-02T00:30:00+02:00", want: NewDate(2025, time.January, 2)},
+		{value: "", want: Date{}},
+		{value: "2025/01/02", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDate(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDate(%q) = %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want.Time) {
+				t.Errorf("ParseDate(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestampUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    time.Time
+		wantErr string
+	}{
+		{data: `"2025-01-02T15:04:05Z"`, want: time.Date(2025, time.January, 2, 15, 4, 5, 0, time.UTC)},
+		{data: `"2025-01-02 15:04:05"`, want: time.Date(2025, time.January, 2, 15, 4, 5, 0, time.UTC)},
+		{data: `"2025-01-02"`, want: time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{data: `""`},
+		{data: `null`},
+		{data: `1735830245`, wantErr: "must be a string"},
+		{data: `true`, wantErr: "must be a string"},
+		{data: `{"time":"2025-01-02"}`, wantErr: "must be a string"},
+		{data: `"tomorrow"`, wantErr: `invalid timestamp "tomorrow"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			// A previous value must be overwritten, null included
+			ts := TimestampOf(time.Now())
+			err := json.Unmarshal([]byte(tt.data), &ts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Unmarshal(%s) error = %v, want %q", tt.data, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !ts.Equal(tt.want) {
+				t.Errorf("Unmarshal(%s) = %v, %v, want %v", tt.data, ts, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    Date
+		wantErr string
+	}{
+		{data: `"2025-01-02"`, want: NewDate(2025, time.January, 2)},
+		{data: `"2025-01-02T15:04:05Z"`, want: NewDate(2025, time.January, 2)},
+		{data: `"2025-01-02 15:04:05"`, want: NewDate(2025, time.January, 2)},
+		{data: `""`},
+		{data: `null`},
+		{data: `20250102`, wantErr: "must be a string"},
+		{data: `["2025-01-02"]`, wantErr: "must be a string"},
+		{data: `"02-01-2025"`, wantErr: `invalid date "02-01-2025"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			d := NewDate(1999, time.December, 31)
+			err := json.Unmarshal([]byte(tt.data), &d)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Unmarshal(%s) error = %v, want %q", tt.data, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !d.Equal(tt.want.Time) {
+				t.Errorf("Unmarshal(%s) = %v, %v, want %v", tt.data, d, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateRoundTrip(t *testing.T) {
+	type document struct {
+		Timestamp Timestamp `json:"timestamp"`
+		Date      Date      `json:"date"`
+	}
+
+	in := document{
+		Timestamp: TimestampOf(time.Date(2025, time.January, 2, 15, 4, 5, 123000000, time.FixedZone("CST", -6*60*60))),
+		Date:      NewDate(2025, time.February, 28),
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"timestamp":"2025-01-02T15:04:05.123-06:00","date":"2025-02-28"}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var out document
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !out.Timestamp.Equal(in.Timestamp.Time) || !out.Date.Equal(in.Date.Time) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestZeroDatesMarshalAsNull(t *testing.T) {
+	// Zero values are sent as null, a deliberate change from the string fields that sent ""
+	data, err := json.Marshal(struct {
+		Timestamp Timestamp `json:"timestamp"`
+		Date      Date      `json:"date"`
+		Omitted   Timestamp `json:"omitted,omitzero"`
+	}{})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"timestamp":null,"date":null}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	// null decodes back to the zero value
+	var zero struct {
+		Timestamp Timestamp `json:"timestamp"`
+		Date      Date      `json:"date"`
+	}
+	if err := json.Unmarshal(data, &zero); err != nil || !zero.Timestamp.IsZero() || !zero.Date.IsZero() {
+		t.Errorf("Unmarshal(%s) = %+v, %v, want zero values", data, zero, err)
+	}
+
+	// Query parameters are left empty instead
+	if text, _ := (Timestamp{}).MarshalText(); len(text) != 0 {
+		t.Errorf("Timestamp{}.MarshalText() = %q, want empty", text)
+	}
+	if text, _ := (Date{}).MarshalText(); len(text) != 0 {
+		t.Errorf("Date{}.MarshalText() = %q, want empty", text)
+	}
+}
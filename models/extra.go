@@ -0,0 +1,211 @@
+//go:generate go run ../internal/extragen
+
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownFields is matched by the errors reporting fields unknown to the models
+var ErrUnknownFields = errors.New("unknown fields")
+
+// Extra holds the JSON fields of a response that are not known by its model, keyed by name.
+// They are kept when the model is decoded and emitted again when it is encoded, so that
+// fields added by the API are not lost.
+type Extra map[string]json.RawMessage
+
+// UnknownFieldsError lists the fields of a response unknown to the models, see UnknownFields
+type UnknownFieldsError struct {
+	Fields []string
+}
+
+// Error implements the error interface
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnknownFields, strings.Join(e.Fields, ", "))
+}
+
+// Is makes errors.Is(err, ErrUnknownFields) match
+func (e *UnknownFieldsError) Is(target error) bool {
+	return target == ErrUnknownFields
+}
+
+// CheckUnknownFields returns an *UnknownFieldsError when v holds fields unknown to the models
+func CheckUnknownFields(v interface{}) error {
+	if fields := UnknownFields(v); len(fields) > 0 {
+		return &UnknownFieldsError{Fields: fields}
+	}
+	return nil
+}
+
+// UnknownFields returns the path of every field kept in the Extra of v or of the models it
+// contains, such as "data[0].newField", sorted
+func UnknownFields(v interface{}) []string {
+	var fields []string
+	collectUnknownFields(reflect.ValueOf(v), "", &fields)
+	slices.Sort(fields)
+	return fields
+}
+
+// extraType is the type of the Extra fields
+var extraType = reflect.TypeFor[Extra]()
+
+// collectUnknownFields walks v appending the unknown fields found under prefix
+func collectUnknownFields(v reflect.Value, prefix string, fields *[]string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectUnknownFields(v.Elem(), prefix, fields)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collectUnknownFields(v.Index(i), fmt.Sprintf("%s[%d]", prefix, i), fields)
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Type == extraType {
+				for name := range v.Field(i).Interface().(Extra) {
+					*fields = append(*fields, joinFieldPath(prefix, name))
+				}
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			collectUnknownFields(v.Field(i), joinFieldPath(prefix, name), fields)
+		}
+	}
+}
+
+// joinFieldPath appends name to the path prefix
+func joinFieldPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// unmarshalExtra decodes data into v, a pointer to a struct without JSON methods,
+// and returns the fields of data unknown to v
+func unmarshalExtra(data []byte, v interface{}) (Extra, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return nil, err
+	}
+
+	known := knownFields(reflect.TypeOf(v).Elem())
+	var extra Extra
+	for name, value := range fields {
+		if isKnownField(known, name) {
+			continue
+		}
+		if extra == nil {
+			extra = make(Extra)
+		}
+		extra[name] = value
+	}
+
+	return extra, nil
+}
+
+// marshalExtra encodes v, a struct without JSON methods, followed by the fields of extra
+// that v does not define, sorted by name
+func marshalExtra(v interface{}, extra Extra) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	known := knownFields(reflect.TypeOf(v))
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		if !isKnownField(known, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	empty := bytes.Equal(bytes.TrimSpace(data), []byte("{}"))
+	for _, name := range names {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value := extra[name]
+		if !json.Valid(value) {
+			return nil, fmt.Errorf("invalid JSON in extra field %s", name)
+		}
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// knownFieldsCache caches the JSON field names of the models by type
+var knownFieldsCache sync.Map
+
+// knownFields returns the JSON names of the fields of the struct type t
+func knownFields(t reflect.Type) []string {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.([]string)
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+
+	knownFieldsCache.Store(t, names)
+	return names
+}
+
+// isKnownField reports whether name matches one of known, ignoring case as encoding/json does
+func isKnownField(known []string, name string) bool {
+	for _, k := range known {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExtraRoundTrip(t *testing.T) {
+	data := []byte(`{
+		"transactionId": "txn_1",
+		"transactionStatus": "paid",
+		"riskScore": 0.12,
+		"flags": {"manual": true, "tags": ["a", "b"]},
+		"products": [{"name": "Product", "quantity": 1, "lot": "L-9"}]
+	}`)
+
+	var tx Transaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if tx.TransactionId != "txn_1" || tx.TransactionStatus != TransactionStatusPaid {
+		t.Errorf("known fields = %+v", tx)
+	}
+	if string(tx.Extra["riskScore"]) != "0.12" || len(tx.Extra) != 2 {
+		t.Errorf("Extra = %s, want riskScore and flags", tx.Extra)
+	}
+	if string(tx.Products[0].Extra["lot"]) != `"L-9"` {
+		t.Errorf("product Extra = %s, want lot", tx.Products[0].Extra)
+	}
+
+	encoded, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(encoded), `"flags":{"manual":true,"tags":["a","b"]},"riskScore":0.12}`) {
+		t.Errorf("Marshal() = %s, want the unknown fields after the known ones, sorted", encoded)
+	}
+
+	var again Transaction
+	if err := json.Unmarshal(encoded, &again); err != nil {
+		t.Fatalf("Unmarshal() of the encoded transaction error = %v", err)
+	}
+	// encoding/json compacts the raw values, the second encoding is identical to the first
+	reencoded, err := json.Marshal(again)
+	if err != nil || string(reencoded) != string(encoded) {
+		t.Errorf("Marshal() after a round trip = %s, %v, want %s", reencoded, err, encoded)
+	}
+}
+
+func TestExtraNoUnknownFields(t *testing.T) {
+	var link TransactionLinkResponse
+	if err := json.Unmarshal([]byte(`{"link":"https://propaga.io/l/1","transactionId":"txn_1"}`), &link); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if link.Extra != nil {
+		t.Errorf("Extra = %v, want nil without unknown fields", link.Extra)
+	}
+
+	var null TransactionLinkResponse
+	if err := json.Unmarshal([]byte(`null`), &null); err != nil || null.Extra != nil {
+		t.Errorf("Unmarshal(null) = %+v, %v", null, err)
+	}
+}
+
+func TestMarshalExtraIntoEmptyObject(t *testing.T) {
+	type empty struct {
+		Name string `json:"name,omitempty"`
+	}
+
+	tests := []struct {
+		name  string
+		v     interface{}
+		extra Extra
+		want  string
+	}{
+		{name: "empty object", v: empty{}, extra: Extra{"b": json.RawMessage(`2`), "a": json.RawMessage(`"1"`)}, want: `{"a":"1","b":2}`},
+		{name: "known fields", v: empty{Name: "x"}, extra: Extra{"a": json.RawMessage(`[]`)}, want: `{"name":"x","a":[]}`},
+		{name: "no extra", v: empty{Name: "x"}, extra: nil, want: `{"name":"x"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := marshalExtra(tt.v, tt.extra)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("marshalExtra() = %s, %v, want %s", got, err, tt.want)
+			}
+			if !json.Valid(got) {
+				t.Errorf("marshalExtra() = %s, not valid JSON", got)
+			}
+		})
+	}
+}
+
+func TestMarshalExtraInvalidJSON(t *testing.T) {
+	tx := Transaction{Extra: Extra{"broken": json.RawMessage(`{"a":`)}}
+	if _, err := json.Marshal(tx); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("Marshal() error = %v, want the invalid extra field reported", err)
+	}
+}
+
+func TestExtraKeyCollisions(t *testing.T) {
+	tx := Transaction{
+		TransactionId: "txn_1",
+		Extra: Extra{
+			"transactionId":     json.RawMessage(`"txn_other"`),
+			"TRANSACTIONSTATUS": json.RawMessage(`"cancel"`),
+			"newField":          json.RawMessage(`true`),
+		},
+	}
+
+	data, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	// Known fields win, compared without case as encoding/json does, and keys are not duplicated
+	if strings.Count(string(data), `"transactionId"`) != 1 || strings.Contains(string(data), "txn_other") ||
+		strings.Contains(strings.ToLower(string(data)), `"transactionstatus":"cancel"`) {
+		t.Errorf("Marshal() = %s, want the known fields only once with their own values", data)
+	}
+
+	var decoded Transaction
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.TransactionId != "txn_1" || !reflect.DeepEqual(decoded.Extra, Extra{"newField": json.RawMessage(`true`)}) {
+		t.Errorf("decoded = %s %s, want txn_1 and only newField in Extra", decoded.TransactionId, decoded.Extra)
+	}
+
+	// A differently cased known field is decoded, not kept as unknown
+	var cased Transaction
+	if err := json.Unmarshal([]byte(`{"TransactionID":"txn_2"}`), &cased); err != nil || cased.TransactionId != "txn_2" || cased.Extra != nil {
+		t.Errorf("Unmarshal() = %s %s, %v", cased.TransactionId, cased.Extra, err)
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	var list TransactionListResponse
+	data := []byte(`{
+		"data": [
+			{"transactionId": "txn_1"},
+			{"transactionId": "txn_2", "channel": "app", "products": [{"name": "x"}, {"name": "y", "lot": "L-1"}]}
+		],
+		"total_count": 2,
+		"next_cursor": "abc"
+	}`)
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := []string{"data[1].channel", "data[1].products[1].lot", "next_cursor"}
+	if got := UnknownFields(&list); !slices.Equal(got, want) {
+		t.Errorf("UnknownFields() = %v, want %v", got, want)
+	}
+
+	err := CheckUnknownFields(list)
+	var unknown *UnknownFieldsError
+	if !errors.Is(err, ErrUnknownFields) || !errors.As(err, &unknown) || !slices.Equal(unknown.Fields, want) {
+		t.Fatalf("CheckUnknownFields() error = %v, want an *UnknownFieldsError", err)
+	}
+	if want := "unknown fields: data[1].channel, data[1].products[1].lot, next_cursor"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	// Params without Extra, nested models included
+	params := &TransactionCreateParams{Products: []Product{{Name: "x"}}}
+	if err := CheckUnknownFields(params); err != nil {
+		t.Errorf("CheckUnknownFields(params) = %v, want nil", err)
+	}
+	if got := UnknownFields(nil); got != nil {
+		t.Errorf("UnknownFields(nil) = %v, want nil", got)
+	}
+}
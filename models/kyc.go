@@ -2,20 +2,22 @@ package models
 
 // KYC represents a Know Your Customer verification in the Propaga system
 type KYC struct {
-	ID           string                 `json:"id"`
-	CustomerID   string                 `json:"customer_id"`
-	Status       string                 `json:"status"`
-	DocumentType string                 `json:"document_type"`
-	DocumentID   string                 `json:"document_id"`
-	FullName     string                 `json:"full_name"`
-	DateOfBirth  string                 `json:"date_of_birth,omitempty"`
-	Address      string                 `json:"address,omitempty"`
-	CreatedAt    string                 `json:"created_at"`
-	UpdatedAt    string                 `json:"updated_at"`
-	VerifiedAt   string                 `json:"verified_at,omitempty"`
-	RejectedAt   string                 `json:"rejected_at,omitempty"`
-	RejectReason string                 `json:"reject_reason,omitempty"`
-	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	ID           string    `json:"id"`
+	CustomerID   string    `json:"customer_id"`
+	Status       string    `json:"status"`
+	DocumentType string    `json:"document_type"`
+	DocumentID   string    `json:"document_id"`
+	FullName     string    `json:"full_name"`
+	DateOfBirth  Date      `json:"date_of_birth,omitzero"`
+	Address      string    `json:"address,omitempty"`
+	CreatedAt    Timestamp `json:"created_at"`
+	UpdatedAt    Timestamp `json:"updated_at"`
+	VerifiedAt   Timestamp `json:"verified_at,omitzero"`
+	RejectedAt   Timestamp `json:"rejected_at,omitzero"`
+	RejectReason string    `json:"reject_reason,omitempty"`
+	Metadata     Metadata  `json:"metadata,omitempty"`
+
+	Extra Extra `json:"-"`
 }
 
 // KYCStatus represents the possible states of a KYC verification
@@ -35,35 +37,35 @@ const (
 
 // KYCListParams represents the parameters for listing KYC verifications
 type KYCListParams struct {
-	Limit      int    `json:"limit,omitempty"`
-	Offset     int    `json:"offset,omitempty"`
-	CustomerID string `json:"customer_id,omitempty"`
-	Status     string `json:"status,omitempty"`
-	StartDate  string `json:"start_date,omitempty"`
-	EndDate    string `json:"end_date,omitempty"`
+	Limit      int    `json:"limit,omitempty" url:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty" url:"offset,omitempty"`
+	CustomerID string `json:"customer_id,omitempty" url:"customer_id,omitempty"`
+	Status     string `json:"status,omitempty" url:"status,omitempty"`
+	StartDate  Date   `json:"start_date,omitzero" url:"start_date,omitempty"`
+	EndDate    Date   `json:"end_date,omitzero" url:"end_date,omitempty"`
 }
 
 // KYCCreateParams represents the parameters for creating a KYC verification
 type KYCCreateParams struct {
-	CustomerID   string                 `json:"customer_id"`
-	DocumentType string                 `json:"document_type"`
-	DocumentID   string                 `json:"document_id"`
-	FullName     string                 `json:"full_name"`
-	DateOfBirth  string                 `json:"date_of_birth,omitempty"`
-	Address      string                 `json:"address,omitempty"`
-	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	CustomerID   string   `json:"customer_id"`
+	DocumentType string   `json:"document_type"`
+	DocumentID   string   `json:"document_id"`
+	FullName     string   `json:"full_name"`
+	DateOfBirth  Date     `json:"date_of_birth,omitzero"`
+	Address      string   `json:"address,omitempty"`
+	Metadata     Metadata `json:"metadata,omitempty"`
 }
 
 // KYCUpdateParams represents the parameters for updating a KYC verification
 type KYCUpdateParams struct {
-	Status       string                 `json:"status,omitempty"`
-	DocumentType string                 `json:"document_type,omitempty"`
-	DocumentID   string                 `json:"document_id,omitempty"`
-	FullName     string                 `json:"full_name,omitempty"`
-	DateOfBirth  string                 `json:"date_of_birth,omitempty"`
-	Address      string                 `json:"address,omitempty"`
-	RejectReason string                 `json:"reject_reason,omitempty"`
-	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	DocumentType string   `json:"document_type,omitempty"`
+	DocumentID   string   `json:"document_id,omitempty"`
+	FullName     string   `json:"full_name,omitempty"`
+	DateOfBirth  Date     `json:"date_of_birth,omitzero"`
+	Address      string   `json:"address,omitempty"`
+	RejectReason string   `json:"reject_reason,omitempty"`
+	Metadata     Metadata `json:"metadata,omitempty"`
 }
 
 // KYCListResponse represents the response when listing KYC verifications
@@ -72,4 +74,6 @@ type KYCListResponse struct {
 	TotalCount int   `json:"total_count"`
 	Limit      int   `json:"limit"`
 	Offset     int   `json:"offset"`
+
+	Extra Extra `json:"-"`
 }
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Keys of the metadata known by the API
+const (
+	MetadataSuccessURL = "success_url"
+	MetadataErrorURL   = "error_url"
+)
+
+// ErrMetadataKeyNotFound is returned by GetMetadata when the key is not set
+var ErrMetadataKeyNotFound = errors.New("metadata key not found")
+
+// Metadata holds the arbitrary key-value pairs attached to a resource.
+// Every key is preserved when it is decoded and encoded again, and numbers are
+// kept as json.Number so that they round-trip without losing precision.
+type Metadata map[string]interface{}
+
+// SuccessURL returns the URL the customer is redirected to once a transaction is accepted
+func (m Metadata) SuccessURL() string {
+	return m.GetString(MetadataSuccessURL)
+}
+
+// ErrorURL returns the URL the customer is redirected to when a transaction fails
+func (m Metadata) ErrorURL() string {
+	return m.GetString(MetadataErrorURL)
+}
+
+// GetString returns the value of key when it is a string, empty otherwise
+func (m Metadata) GetString(key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// SetSuccessURL sets the URL the customer is redirected to once a transaction is accepted
+func (m *Metadata) SetSuccessURL(url string) {
+	m.set(MetadataSuccessURL, url)
+}
+
+// SetErrorURL sets the URL the customer is redirected to when a transaction fails
+func (m *Metadata) SetErrorURL(url string) {
+	m.set(MetadataErrorURL, url)
+}
+
+// UnmarshalJSON decodes the metadata keeping numbers as json.Number
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*m = nil
+		return nil
+	}
+
+	var values map[string]interface{}
+	if err := decodeJSONNumber(data, &values); err != nil {
+		return err
+	}
+	*m = values
+	return nil
+}
+
+// set stores value under key, allocating the map when needed
+func (m *Metadata) set(key string, value interface{}) {
+	if *m == nil {
+		*m = make(Metadata)
+	}
+	(*m)[key] = value
+}
+
+// GetMetadata decodes the value stored under key into a T, such as a struct of the caller.
+// It returns ErrMetadataKeyNotFound when the key is not set.
+func GetMetadata[T any](m Metadata, key string) (T, error) {
+	var result T
+
+	value, ok := m[key]
+	if !ok {
+		return result, fmt.Errorf("%w: %s", ErrMetadataKeyNotFound, key)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return result, fmt.Errorf("error encoding metadata %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("error decoding metadata %s: %w", key, err)
+	}
+
+	return result, nil
+}
+
+// SetMetadata encodes value as JSON and stores it under key, allocating the metadata when nil.
+// The value is stored in its decoded JSON form, as if it had been received from the API.
+func SetMetadata[T any](m *Metadata, key string, value T) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error encoding metadata %s: %w", key, err)
+	}
+
+	var decoded interface{}
+	if err := decodeJSONNumber(data, &decoded); err != nil {
+		return fmt.Errorf("error encoding metadata %s: %w", key, err)
+	}
+
+	m.set(key, decoded)
+	return nil
+}
+
+// decodeJSONNumber decodes data into v keeping numbers as json.Number
+func decodeJSONNumber(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// order is a caller-defined struct stored in metadata
+type order struct {
+	Number   string            `json:"number"`
+	Total    int64             `json:"total"`
+	Tags     []string          `json:"tags"`
+	Delivery *Date             `json:"delivery,omitempty"`
+	Labels   map[string]string `json:"labels"`
+}
+
+func TestGetMetadataMissingKey(t *testing.T) {
+	for name, m := range map[string]Metadata{"nil": nil, "empty": {}, "other keys": {"other": "value"}} {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetMetadata[string](m, "order")
+			if !errors.Is(err, ErrMetadataKeyNotFound) || got != "" {
+				t.Errorf("GetMetadata() = %q, %v, want ErrMetadataKeyNotFound", got, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "order") {
+				t.Errorf("error %q does not name the key", err)
+			}
+		})
+	}
+}
+
+func TestGetMetadataTypeMismatch(t *testing.T) {
+	m := Metadata{
+		"name":  "store",
+		"count": json.Number("3"),
+		"order": map[string]interface{}{"number": 12},
+	}
+
+	if got, err := GetMetadata[int](m, "name"); err == nil || errors.Is(err, ErrMetadataKeyNotFound) {
+		t.Errorf("GetMetadata[int](name) = %v, %v, want a decoding error", got, err)
+	}
+	if got, err := GetMetadata[string](m, "count"); err == nil {
+		t.Errorf("GetMetadata[string](count) = %q, want a decoding error", got)
+	}
+	if _, err := GetMetadata[order](m, "order"); err == nil || !strings.Contains(err.Error(), "error decoding metadata order") {
+		t.Errorf("GetMetadata[order](order) error = %v, want a decoding error naming the key", err)
+	}
+
+	// Compatible types convert through JSON
+	if got, err := GetMetadata[float64](m, "count"); err != nil || got != 3 {
+		t.Errorf("GetMetadata[float64](count) = %v, %v, want 3", got, err)
+	}
+}
+
+func TestSetMetadataNilMap(t *testing.T) {
+	var m Metadata
+	if err := SetMetadata(&m, "source", "checkout"); err != nil {
+		t.Fatalf("SetMetadata() error = %v", err)
+	}
+	if m == nil || m["source"] != "checkout" {
+		t.Errorf("metadata = %v, want the allocated map holding the value", m)
+	}
+
+	// A value that cannot be encoded leaves the metadata untouched
+	var untouched Metadata
+	if err := SetMetadata(&untouched, "callback", func() {}); err == nil || !strings.Contains(err.Error(), "error encoding metadata callback") {
+		t.Errorf("SetMetadata(func) error = %v, want an encoding error", err)
+	}
+	if untouched != nil {
+		t.Errorf("metadata = %v after a failed SetMetadata, want nil", untouched)
+	}
+}
+
+func TestSetMetadataStoresDecodedJSON(t *testing.T) {
+	m := Metadata{}
+	if err := SetMetadata(&m, "order", order{Number: "A-1", Total: 9007199254740993}); err != nil {
+		t.Fatalf("SetMetadata() error = %v", err)
+	}
+
+	stored, ok := m["order"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("stored %T, want the decoded JSON object", m["order"])
+	}
+	// Numbers stay exact past the precision of float64
+	if stored["total"] != json.Number("9007199254740993") {
+		t.Errorf("total = %#v, want json.Number(9007199254740993)", stored["total"])
+	}
+}
+
+func TestMetadataRoundTrip(t *testing.T) {
+	delivery := NewDate(2025, 1, 2)
+	want := order{
+		Number:   "A-1",
+		Total:    9007199254740993,
+		Tags:     []string{"fragile", "priority"},
+		Delivery: &delivery,
+		Labels:   map[string]string{"channel": "app"},
+	}
+
+	var m Metadata
+	m.SetSuccessURL("https://example.com/ok")
+	if err := SetMetadata(&m, "order", want); err != nil {
+		t.Fatalf("SetMetadata() error = %v", err)
+	}
+
+	// Through the wire and back, as when the API echoes the metadata
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var received Metadata
+	if err := json.Unmarshal(data, &received); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	got, err := GetMetadata[order](received, "order")
+	if err != nil {
+		t.Fatalf("GetMetadata() error = %v", err)
+	}
+	if got.Delivery == nil || !got.Delivery.Equal(delivery.Time) {
+		t.Errorf("delivery = %v, want %v", got.Delivery, delivery)
+	}
+	got.Delivery, want.Delivery = nil, nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMetadata() = %+v, want %+v", got, want)
+	}
+	if received.SuccessURL() != "https://example.com/ok" {
+		t.Errorf("SuccessURL() = %q after the round trip", received.SuccessURL())
+	}
+}
+
+func TestMetadataUnmarshalNull(t *testing.T) {
+	m := Metadata{"stale": "value"}
+	if err := json.Unmarshal([]byte(`null`), &m); err != nil || m != nil {
+		t.Errorf("Unmarshal(null) = %v, %v, want nil metadata", m, err)
+	}
+}
@@ -0,0 +1,260 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// DefaultCurrency is the currency of the amounts exchanged with the Propaga API
+const DefaultCurrency = "MXN"
+
+// ErrSubCentavoAmount is returned when decoding an amount more precise than a centavo,
+// which cannot be represented exactly by Money
+var ErrSubCentavoAmount = errors.New("money amount more precise than a centavo")
+
+// Money is an exact monetary amount stored as an integer number of centavos (minor units)
+// plus an ISO 4217 currency code. An empty currency means DefaultCurrency.
+//
+// Money is encoded in JSON as a plain decimal number, the format used by the API,
+// so amounts such as 1234.56 round-trip without floating point errors.
+type Money struct {
+	cents    int64
+	currency string
+}
+
+// NewMoney creates an amount from a number of centavos and a currency code
+func NewMoney(cents int64, currency string) Money {
+	return Money{cents: cents, currency: normalizeCurrency(currency)}
+}
+
+// MXN creates an amount in Mexican pesos from a number of centavos
+func MXN(cents int64) Money {
+	return NewMoney(cents, DefaultCurrency)
+}
+
+// ParseMoney parses a decimal amount such as "1234.56" in the given currency.
+// Digits beyond the centavos are rounded half away from zero.
+func ParseMoney(amount, currency string) (Money, error) {
+	cents, err := parseCents(amount, true)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(cents, currency), nil
+}
+
+// Cents returns the amount in centavos
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Currency returns the ISO 4217 currency code of the amount
+func (m Money) Currency() string {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+// WithCurrency returns the same amount in another currency, without conversion
+func (m Money) WithCurrency(currency string) Money {
+	return NewMoney(m.cents, currency)
+}
+
+// Add returns m + o. It panics if the currencies differ.
+func (m Money) Add(o Money) Money {
+	m.mustMatch(o)
+	return Money{cents: m.cents + o.cents, currency: m.currency}
+}
+
+// Sub returns m - o. It panics if the currencies differ.
+func (m Money) Sub(o Money) Money {
+	m.mustMatch(o)
+	return Money{cents: m.cents - o.cents, currency: m.currency}
+}
+
+// Mul returns m multiplied by n
+func (m Money) Mul(n int64) Money {
+	return Money{cents: m.cents * n, currency: m.currency}
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{cents: -m.cents, currency: m.currency}
+}
+
+// Abs returns the absolute value of m
+func (m Money) Abs() Money {
+	if m.cents < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Cmp compares m and o and returns -1, 0 or +1. It panics if the currencies differ.
+func (m Money) Cmp(o Money) int {
+	m.mustMatch(o)
+	switch {
+	case m.cents < o.cents:
+		return -1
+	case m.cents > o.cents:
+		return 1
+	}
+	return 0
+}
+
+// Equal reports whether m and o hold the same amount in the same currency
+func (m Money) Equal(o Money) bool {
+	return m.cents == o.cents && m.Currency() == o.Currency()
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+// IsNegative reports whether the amount is lower than zero
+func (m Money) IsNegative() bool {
+	return m.cents < 0
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.cents > 0
+}
+
+// Float64 returns the amount in major units as a float64, for display purposes only
+func (m Money) Float64() float64 {
+	return float64(m.cents) / 100
+}
+
+// Decimal returns the amount in major units with two decimals, such as "1234.56"
+func (m Money) Decimal() string {
+	sign := ""
+	cents := m.cents
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// String returns the amount followed by its currency, such as "1234.56 MXN"
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Decimal(), m.Currency())
+}
+
+// Format returns the amount with a currency symbol and thousands separators, such as "$1,234.56 MXN"
+func (m Money) Format() string {
+	decimal := m.Decimal()
+	sign := ""
+	if strings.HasPrefix(decimal, "-") {
+		sign = "-"
+		decimal = decimal[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(decimal, ".")
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return fmt.Sprintf("%s$%s.%s %s", sign, grouped.String(), fraction, m.Currency())
+}
+
+// MarshalJSON encodes the amount as a decimal number
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON decodes a decimal number, a string holding one, or null.
+// The currency of m is kept, the API does not send it along with the amounts.
+// Amounts with digits beyond the centavos are refused with ErrSubCentavoAmount
+// rather than rounded, so that decoding never alters what the API sent.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		m.cents = 0
+		return nil
+	}
+
+	text := string(data)
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("invalid money amount %s: %w", data, err)
+		}
+		if text == "" {
+			m.cents = 0
+			return nil
+		}
+	}
+
+	cents, err := parseCents(text, false)
+	if err != nil {
+		return err
+	}
+	m.cents = cents
+
+	return nil
+}
+
+// mustMatch panics when m and o are expressed in different currencies
+func (m Money) mustMatch(o Money) {
+	if m.Currency() != o.Currency() {
+		panic(fmt.Sprintf("models: mixing currencies %s and %s", m.Currency(), o.Currency()))
+	}
+}
+
+// decimalPattern matches the decimal numbers accepted as money amounts
+var decimalPattern = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][+-]?\d+)?$`)
+
+// parseCents converts a decimal amount in major units to centavos. Digits beyond the
+// centavos are rounded half away from zero when round is set, refused otherwise.
+func parseCents(amount string, round bool) (int64, error) {
+	amount = strings.TrimSpace(amount)
+	if !decimalPattern.MatchString(amount) {
+		return 0, fmt.Errorf("invalid money amount %q", amount)
+	}
+
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return 0, fmt.Errorf("invalid money amount %q", amount)
+	}
+
+	r.Mul(r, big.NewRat(100, 1))
+	if !round && !r.IsInt() {
+		return 0, fmt.Errorf("%w: %q", ErrSubCentavoAmount, amount)
+	}
+
+	// Round half away from zero to the nearest centavo
+	num, den := r.Num(), r.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(den) >= 0 {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("money amount %q out of range", amount)
+	}
+
+	return q.Int64(), nil
+}
+
+// normalizeCurrency returns the upper case currency code, empty for the default currency
+func normalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == DefaultCurrency {
+		return ""
+	}
+	return currency
+}
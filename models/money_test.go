@@ -0,0 +1,172 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    int64
+		wantErr bool
+	}{
+		{amount: "0", want: 0},
+		{amount: "1234.56", want: 123456},
+		{amount: "1234.5", want: 123450},
+		{amount: "-10.01", want: -1001},
+		{amount: " 7 ", want: 700},
+		{amount: "1e2", want: 10000},
+		{amount: "12.345", want: 1235},
+		{amount: "12.344", want: 1234},
+		{amount: "-12.345", want: -1235},
+		{amount: "0.005", want: 1},
+		{amount: "", wantErr: true},
+		{amount: "abc", wantErr: true},
+		{amount: "1,000.00", wantErr: true},
+		{amount: "$10", wantErr: true},
+		{amount: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			got, err := ParseMoney(tt.amount, "mxn")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMoney(%q) error = %v, wantErr %v", tt.amount, err, tt.wantErr)
+			}
+			if err == nil && (got.Cents() != tt.want || got.Currency() != DefaultCurrency) {
+				t.Errorf("ParseMoney(%q) = %d %s, want %d %s", tt.amount, got.Cents(), got.Currency(), tt.want, DefaultCurrency)
+			}
+		})
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    int64
+		wantErr error
+	}{
+		{json: `1234.56`, want: 123456},
+		{json: `"1234.56"`, want: 123456},
+		{json: `12.340`, want: 1234},
+		{json: `100`, want: 10000},
+		{json: `null`, want: 0},
+		{json: `""`, want: 0},
+		{json: `12.345`, wantErr: ErrSubCentavoAmount},
+		{json: `"0.001"`, wantErr: ErrSubCentavoAmount},
+		{json: `true`, wantErr: errAny},
+		{json: `"ten"`, wantErr: errAny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			m := NewMoney(1, "USD")
+			err := json.Unmarshal([]byte(tt.json), &m)
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("Unmarshal(%s) error = %v", tt.json, err)
+			case tt.wantErr == errAny && err == nil, tt.wantErr != nil && tt.wantErr != errAny && !errors.Is(err, tt.wantErr):
+				t.Fatalf("Unmarshal(%s) error = %v, want %v", tt.json, err, tt.wantErr)
+			case tt.wantErr == nil && (m.Cents() != tt.want || m.Currency() != "USD"):
+				t.Errorf("Unmarshal(%s) = %s, want %d centavos in USD", tt.json, m, tt.want)
+			}
+		})
+	}
+}
+
+// errAny marks test cases expecting any error
+var errAny = errors.New("any error")
+
+func TestMoneyJSONRoundTrip(t *testing.T) {
+	for _, in := range []string{`{"a":1234.56}`, `{"a":-0.05}`, `{"a":0.00}`, `{"a":1000000.10}`} {
+		var v struct{ A Money }
+		if err := json.Unmarshal([]byte(in), &v); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", in, err)
+		}
+		out, err := json.Marshal(map[string]Money{"a": v.A})
+		if err != nil {
+			t.Fatalf("Marshal error = %v", err)
+		}
+		if string(out) != in {
+			t.Errorf("round-trip of %s = %s", in, out)
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: MXN(0), want: "$0.00 MXN"},
+		{money: MXN(5), want: "$0.05 MXN"},
+		{money: MXN(99999), want: "$999.99 MXN"},
+		{money: MXN(123456), want: "$1,234.56 MXN"},
+		{money: MXN(123456789), want: "$1,234,567.89 MXN"},
+		{money: MXN(-100000), want: "-$1,000.00 MXN"},
+		{money: NewMoney(250, "usd"), want: "$2.50 USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.money.Format(); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyDecimalAndString(t *testing.T) {
+	if got := MXN(-5).Decimal(); got != "-0.05" {
+		t.Errorf("Decimal() = %q, want %q", got, "-0.05")
+	}
+	if got := NewMoney(123456, "eur").String(); got != "1234.56 EUR" {
+		t.Errorf("String() = %q, want %q", got, "1234.56 EUR")
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a, b := MXN(1050), MXN(250)
+
+	if got := a.Add(b); !got.Equal(MXN(1300)) {
+		t.Errorf("Add() = %s", got)
+	}
+	if got := b.Sub(a); !got.Equal(MXN(-800)) || !got.IsNegative() || !got.Abs().Equal(MXN(800)) {
+		t.Errorf("Sub() = %s", got)
+	}
+	if got := b.Mul(3); !got.Equal(MXN(750)) {
+		t.Errorf("Mul() = %s", got)
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(a) != 0 {
+		t.Errorf("Cmp() inconsistent")
+	}
+	if MXN(100).Equal(NewMoney(100, "USD")) {
+		t.Errorf("Equal() ignores the currency")
+	}
+	if !NewMoney(100, "").Equal(MXN(100)) {
+		t.Errorf("Equal() distinguishes the empty and the default currency")
+	}
+}
+
+func TestMoneyCurrencyMismatchPanics(t *testing.T) {
+	mxn, usd := MXN(100), NewMoney(100, "USD")
+
+	tests := map[string]func(){
+		"Add": func() { mxn.Add(usd) },
+		"Sub": func() { mxn.Sub(usd) },
+		"Cmp": func() { mxn.Cmp(usd) },
+	}
+
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic on mixed currencies", name)
+				}
+			}()
+			f()
+		})
+	}
+}
@@ -1,25 +1,25 @@
 package models
 
-import "time"
-
 // Transaction represents a transaction in the Propaga system
 type Transaction struct {
-	TransactionId            string    `json:"transactionId"`
-	CornerStoreId            string    `json:"cornerStoreId"`
-	UserId                   string    `json:"userId"`
-	TransactionStatus        string    `json:"transactionStatus"`
-	WholesalerTransactionId  string    `json:"wholesalerTransactionId"`
-	MovementDate             time.Time `json:"movementDate"`
-	TotalAmount              float64   `json:"totalAmount"`
-	WholesalerFees           float64   `json:"wholesalerFees"`
-	Interests                float64   `json:"interests"`
-	IVAAmount                float64   `json:"IVAAmount"`
-	TotalAmountWithInterests float64   `json:"totalAmountWithInterests"`
-	PaymentDate              time.Time `json:"paymentDate"`
-	DeliveryDate             time.Time `json:"deliveryDate"`
-	Wholesaler               string    `json:"wholesaler"`
-	Products                 []Product `json:"products"`
-	Metadata                 Metadata  `json:"metadata,omitempty"`
+	TransactionId            string            `json:"transactionId"`
+	CornerStoreId            string            `json:"cornerStoreId"`
+	UserId                   string            `json:"userId"`
+	TransactionStatus        TransactionStatus `json:"transactionStatus"`
+	WholesalerTransactionId  string            `json:"wholesalerTransactionId"`
+	MovementDate             Timestamp         `json:"movementDate"`
+	TotalAmount              Money             `json:"totalAmount"`
+	WholesalerFees           Money             `json:"wholesalerFees"`
+	Interests                Money             `json:"interests"`
+	IVAAmount                Money             `json:"IVAAmount"`
+	TotalAmountWithInterests Money             `json:"totalAmountWithInterests"`
+	PaymentDate              Timestamp         `json:"paymentDate"`
+	DeliveryDate             Timestamp         `json:"deliveryDate"`
+	Wholesaler               string            `json:"wholesaler"`
+	Products                 []Product         `json:"products"`
+	Metadata                 Metadata          `json:"metadata,omitempty"`
+
+	Extra Extra `json:"-"`
 }
 
 type Product struct {
@@ -27,53 +27,51 @@ type Product struct {
 	ExternalSKU string    `json:"externalSKU"`
 	Name        string    `json:"name"`
 	Quantity    int       `json:"quantity"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-}
+	CreatedAt   Timestamp `json:"createdAt,omitzero"`
+	UpdatedAt   Timestamp `json:"updatedAt,omitzero"`
 
-type Metadata struct {
-	SuccessUrl string `json:"success_url"`
-	ErrorUrl   string `json:"error_url"`
+	Extra Extra `json:"-"`
 }
 
-// TransactionStatus represents the possible states of a transaction
-const (
-	TransactionStatusPending   = "pending-verification"
-	TransactionStatusOnHold    = "on-hold"
-	TransactionStatusDelivery  = "delivery"
-	TransactionStatusCancelled = "cancel"
-	TransactionStatusPaid      = "paid"
-	TransactionStatusExpired   = "expired"
-)
-
 // TransactionListParams represents the parameters for listing transactions
 type TransactionListParams struct {
-	Limit      int    `json:"limit,omitempty"`
-	Offset     int    `json:"offset,omitempty"`
-	CustomerID string `json:"customer_id,omitempty"`
-	Status     string `json:"status,omitempty"`
-	StartDate  string `json:"start_date,omitempty"`
-	EndDate    string `json:"end_date,omitempty"`
+	Limit      int               `json:"limit,omitempty" url:"limit,omitempty"`
+	Offset     int               `json:"offset,omitempty" url:"offset,omitempty"`
+	CustomerID string            `json:"customer_id,omitempty" url:"customer_id,omitempty"`
+	Status     TransactionStatus `json:"status,omitempty" url:"status,omitempty"`
+	StartDate  Date              `json:"start_date,omitzero" url:"start_date,omitempty"`
+	EndDate    Date              `json:"end_date,omitzero" url:"end_date,omitempty"`
 }
 
 // TransactionCreateParams represents the parameters for creating a transaction
 type TransactionCreateParams struct {
-	CornerStoreId           string                 `json:"cornerStoreId"`
-	TotalAmount             float64                `json:"totalAmount"`
-	WholesalerTransactionId string                 `json:"wholesalerTransactionId"`
-	DeliveryDate            string                 `json:"deliveryDate"`
-	Products                []Product              `json:"products"`
-	Metadata                map[string]interface{} `json:"metadata,omitempty"`
+	CornerStoreId           string    `json:"cornerStoreId"`
+	TotalAmount             Money     `json:"totalAmount"`
+	WholesalerTransactionId string    `json:"wholesalerTransactionId"`
+	DeliveryDate            Date      `json:"deliveryDate,omitzero"`
+	Products                []Product `json:"products"`
+	Metadata                Metadata  `json:"metadata,omitempty"`
+
+	// IdempotencyKey is sent in the Idempotency-Key header instead of the body.
+	// When empty, a key derived from WholesalerTransactionId is used, so creating
+	// a transaction again with the same WholesalerTransactionId (for instance after
+	// cancelling the first one) replays the original response. Set a new key to
+	// create it again.
+	IdempotencyKey string `json:"-"`
 }
 
 // TransactionUpdateParams represents the parameters for updating a transaction
 type TransactionUpdateParams struct {
-	Status              string                 `json:"status,omitempty"`
-	TransactionAmount   float64                `json:"transactionAmount,omitempty"`
-	Latitude            float64                `json:"latitude,omitempty"`
-	Longitude           float64                `json:"longitude,omitempty"`
-	LocationDescription string                 `json:"locationDescription,omitempty"`
-	Metadata            map[string]interface{} `json:"metadata,omitempty"`
+	Status              TransactionStatus `json:"status,omitempty"`
+	TransactionAmount   Money             `json:"transactionAmount,omitzero"`
+	Latitude            float64           `json:"latitude,omitempty"`
+	Longitude           float64           `json:"longitude,omitempty"`
+	LocationDescription string            `json:"locationDescription,omitempty"`
+	Metadata            Metadata          `json:"metadata,omitempty"`
+
+	// CurrentStatus is the status the caller last saw, it is not sent. When set along with
+	// Status, the transition is checked locally before calling the API.
+	CurrentStatus TransactionStatus `json:"-"`
 }
 
 // TransactionListResponse represents the response when listing transactions
@@ -82,6 +80,8 @@ type TransactionListResponse struct {
 	TotalCount int           `json:"total_count"`
 	Limit      int           `json:"limit"`
 	Offset     int           `json:"offset"`
+
+	Extra Extra `json:"-"`
 }
 
 // APIError represents an error returned by the API
@@ -94,28 +94,42 @@ type APIError struct {
 type TransactionLinkResponse struct {
 	Link          string `json:"link"`
 	TransactionId string `json:"transactionId"`
+
+	Extra Extra `json:"-"`
 }
 
 type TransactionLinkParams struct {
 	Transaction struct {
-		CornerStoreId           string                 `json:"cornerStoreId"`
-		TotalAmount             float64                `json:"totalAmount"`
-		WholesalerTransactionId string                 `json:"wholesalerTransactionId"`
-		Products                []Product              `json:"products"`
-		Metadata                map[string]interface{} `json:"metadata,omitempty"`
+		CornerStoreId           string    `json:"cornerStoreId"`
+		TotalAmount             Money     `json:"totalAmount"`
+		WholesalerTransactionId string    `json:"wholesalerTransactionId"`
+		Products                []Product `json:"products"`
+		Metadata                Metadata  `json:"metadata,omitempty"`
 	} `json:"transaction"`
+
+	// IdempotencyKey is sent in the Idempotency-Key header instead of the body.
+	// When empty, a key derived from Transaction.WholesalerTransactionId is used,
+	// see TransactionCreateParams.IdempotencyKey.
+	IdempotencyKey string `json:"-"`
 }
 
 type PendingTransactionsResponse struct {
-	Transactions []struct {
-		Id                       string    `json:"id"`
-		CornerStoreId            string    `json:"cornerStoreId"`
-		WholesalerTransactionId  string    `json:"wholesalerTransactionId"`
-		TotalAmount              float64   `json:"totalAmount"`
-		Interests                float64   `json:"interests"`
-		IVAAmount                float64   `json:"IVAAmount"`
-		TotalAmountWithInterests float64   `json:"totalAmountWithInterests"`
-		MovementDate             time.Time `json:"movementDate"`
-		DeliveryDate             time.Time `json:"deliveryDate"`
-	} `json:"transactions"`
+	Transactions []PendingTransaction `json:"transactions"`
+
+	Extra Extra `json:"-"`
+}
+
+// PendingTransaction is a transaction awaiting verification, as listed by GetPendingTransactions
+type PendingTransaction struct {
+	Id                       string    `json:"id"`
+	CornerStoreId            string    `json:"cornerStoreId"`
+	WholesalerTransactionId  string    `json:"wholesalerTransactionId"`
+	TotalAmount              Money     `json:"totalAmount"`
+	Interests                Money     `json:"interests"`
+	IVAAmount                Money     `json:"IVAAmount"`
+	TotalAmountWithInterests Money     `json:"totalAmountWithInterests"`
+	MovementDate             Timestamp `json:"movementDate"`
+	DeliveryDate             Timestamp `json:"deliveryDate"`
+
+	Extra Extra `json:"-"`
 }
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TransactionStatus is the status of a transaction in its lifecycle:
+//
+//	pending-verification -> on-hold -> delivery -> paid
+//
+// A transaction can be cancelled or expire before it is delivered.
+// Paid, cancelled and expired are terminal statuses.
+type TransactionStatus string
+
+// TransactionStatus represents the possible states of a transaction
+const (
+	TransactionStatusPending   TransactionStatus = "pending-verification"
+	TransactionStatusOnHold    TransactionStatus = "on-hold"
+	TransactionStatusDelivery  TransactionStatus = "delivery"
+	TransactionStatusCancelled TransactionStatus = "cancel"
+	TransactionStatusPaid      TransactionStatus = "paid"
+	TransactionStatusExpired   TransactionStatus = "expired"
+)
+
+// ErrInvalidTransition is matched by errors.Is for every *TransitionError
+var ErrInvalidTransition = errors.New("invalid transaction status transition")
+
+// transactionTransitions is the graph of the legal transaction status transitions
+var transactionTransitions = map[TransactionStatus][]TransactionStatus{
+	TransactionStatusPending:  {TransactionStatusOnHold, TransactionStatusCancelled, TransactionStatusExpired},
+	TransactionStatusOnHold:   {TransactionStatusDelivery, TransactionStatusCancelled, TransactionStatusExpired},
+	TransactionStatusDelivery: {TransactionStatusPaid},
+}
+
+// TransactionStatuses returns every known transaction status
+func TransactionStatuses() []TransactionStatus {
+	return []TransactionStatus{
+		TransactionStatusPending,
+		TransactionStatusOnHold,
+		TransactionStatusDelivery,
+		TransactionStatusCancelled,
+		TransactionStatusPaid,
+		TransactionStatusExpired,
+	}
+}
+
+// IsValid reports whether the status is one of the known transaction statuses
+func (s TransactionStatus) IsValid() bool {
+	return slices.Contains(TransactionStatuses(), s)
+}
+
+// IsTerminal reports whether the transaction cannot leave the status anymore
+func (s TransactionStatus) IsTerminal() bool {
+	return s.IsValid() && len(transactionTransitions[s]) == 0
+}
+
+// NextStatuses returns the statuses a transaction can move to from s
+func (s TransactionStatus) NextStatuses() []TransactionStatus {
+	return slices.Clone(transactionTransitions[s])
+}
+
+// CanTransitionTo reports whether a transaction can move from s to next
+func (s TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	return slices.Contains(transactionTransitions[s], next)
+}
+
+// ValidateTransition returns a *TransitionError when a transaction cannot move from s to next
+func (s TransactionStatus) ValidateTransition(next TransactionStatus) error {
+	if !s.CanTransitionTo(next) {
+		return &TransitionError{From: s, To: next}
+	}
+	return nil
+}
+
+// String implements fmt.Stringer
+func (s TransactionStatus) String() string {
+	return string(s)
+}
+
+// TransitionError describes an illegal transaction status transition
+type TransitionError struct {
+	From TransactionStatus
+	To   TransactionStatus
+}
+
+// Error implements the error interface
+func (e *TransitionError) Error() string {
+	if !e.To.IsValid() {
+		return fmt.Sprintf("%s: unknown status %q", ErrInvalidTransition, e.To)
+	}
+	if !e.From.IsValid() {
+		return fmt.Sprintf("%s: cannot move from unknown status %q to %q", ErrInvalidTransition, e.From, e.To)
+	}
+	if e.From.IsTerminal() {
+		return fmt.Sprintf("%s: %q is a terminal status and cannot move to %q", ErrInvalidTransition, e.From, e.To)
+	}
+
+	next := make([]string, 0, len(transactionTransitions[e.From]))
+	for _, status := range transactionTransitions[e.From] {
+		next = append(next, fmt.Sprintf("%q", status))
+	}
+	return fmt.Sprintf("%s: cannot move from %q to %q, allowed: %s", ErrInvalidTransition, e.From, e.To, strings.Join(next, ", "))
+}
+
+// Is makes errors.Is(err, ErrInvalidTransition) report true
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransitionErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		from TransactionStatus
+		to   TransactionStatus
+		want string
+	}{
+		{
+			name: "illegal move",
+			from: TransactionStatusPending,
+			to:   TransactionStatusPaid,
+			want: `invalid transaction status transition: cannot move from "pending-verification" to "paid", allowed: "on-hold", "cancel", "expired"`,
+		},
+		{
+			name: "terminal status",
+			from: TransactionStatusPaid,
+			to:   TransactionStatusPaid,
+			want: `invalid transaction status transition: "paid" is a terminal status and cannot move to "paid"`,
+		},
+		{
+			name: "unknown target",
+			from: TransactionStatusPending,
+			to:   "shipped",
+			want: `invalid transaction status transition: unknown status "shipped"`,
+		},
+		{
+			name: "unknown current status",
+			from: "archived",
+			to:   TransactionStatusPaid,
+			want: `invalid transaction status transition: cannot move from unknown status "archived" to "paid"`,
+		},
+		{
+			name: "empty current status",
+			from: "",
+			to:   TransactionStatusPaid,
+			want: `invalid transaction status transition: cannot move from unknown status "" to "paid"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.from.ValidateTransition(tt.to)
+			if !errors.Is(err, ErrInvalidTransition) {
+				t.Fatalf("ValidateTransition() error = %v, want ErrInvalidTransition", err)
+			}
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Error() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
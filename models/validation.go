@@ -0,0 +1,316 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Validation rules reported in FieldError.Rule
+const (
+	RuleRequired = "required"
+	RulePositive = "positive"
+	RuleMinItems = "min_items"
+	RuleFormat   = "format"
+	RuleEnum     = "enum"
+	RuleRange    = "range"
+)
+
+// FieldError describes a parameter that failed validation
+type FieldError struct {
+	// Field is the JSON path of the parameter, such as "products[0].quantity"
+	Field string
+
+	// Rule is the validation rule that failed, one of the Rule constants
+	Rule string
+
+	// Message is a human readable description of the failure
+	Message string
+}
+
+// Error implements the error interface
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError is returned by the Validate methods when parameters are invalid
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		msgs = append(msgs, fieldErr.Error())
+	}
+	return fmt.Sprintf("invalid parameters: %s", strings.Join(msgs, "; "))
+}
+
+// Field returns the errors reported for the given field path
+func (e *ValidationError) Field(field string) []FieldError {
+	var errs []FieldError
+	for _, fieldErr := range e.Errors {
+		if fieldErr.Field == field {
+			errs = append(errs, fieldErr)
+		}
+	}
+	return errs
+}
+
+// phonePattern matches phone numbers once separators are removed:
+// 10 digit national numbers or international numbers with a leading +
+var phonePattern = regexp.MustCompile(`^(\d{10}|\+\d{10,15})$`)
+
+// validator collects the field errors of a validation
+type validator struct {
+	errs []FieldError
+}
+
+// add records a field error
+func (v *validator) add(field, rule, format string, args ...interface{}) {
+	v.errs = append(v.errs, FieldError{Field: field, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+// required checks that value is not blank
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, RuleRequired, "is required")
+	}
+}
+
+// requiredDate checks that value is set
+func (v *validator) requiredDate(field string, value Date) {
+	if value.IsZero() {
+		v.add(field, RuleRequired, "is required")
+	}
+}
+
+// positive checks that amount is greater than zero
+func (v *validator) positive(field string, amount Money) {
+	if !amount.IsPositive() {
+		v.add(field, RulePositive, "must be greater than zero")
+	}
+}
+
+// notNegative checks that amount is not lower than zero
+func (v *validator) notNegative(field string, amount Money) {
+	if amount.IsNegative() {
+		v.add(field, RulePositive, "must not be negative")
+	}
+}
+
+// phone checks the format of a phone number, when set
+func (v *validator) phone(field, value string) {
+	if value == "" {
+		return
+	}
+	normalized := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(value)
+	if !phonePattern.MatchString(normalized) {
+		v.add(field, RuleFormat, "must be a 10 digit phone number or an international number starting with +")
+	}
+}
+
+// email checks the format of an email address, when set
+func (v *validator) email(field, value string) {
+	if value == "" {
+		return
+	}
+	if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+		v.add(field, RuleFormat, "must be a valid email address")
+	}
+}
+
+// dateOfBirth checks that value is in the past, when set
+func (v *validator) dateOfBirth(field string, value Date) {
+	if !value.IsZero() && !value.Before(time.Now()) {
+		v.add(field, RuleRange, "must be in the past")
+	}
+}
+
+// enum checks that value is one of allowed, when set
+func (v *validator) enum(field, value string, allowed ...string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(field, RuleEnum, "must be one of %s", strings.Join(allowed, ", "))
+}
+
+// products checks the products of a transaction
+func (v *validator) products(field string, products []Product) {
+	if len(products) == 0 {
+		v.add(field, RuleMinItems, "must contain at least one product")
+		return
+	}
+	for i, product := range products {
+		path := fmt.Sprintf("%s[%d]", field, i)
+		if product.Name == "" && product.ExternalSKU == "" {
+			v.add(path+".name", RuleRequired, "name or externalSKU is required")
+		}
+		if product.Quantity <= 0 {
+			v.add(path+".quantity", RulePositive, "must be greater than zero")
+		}
+	}
+}
+
+// err returns the collected errors as a *ValidationError, nil when there are none
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errs}
+}
+
+// missingParams is the error returned when required parameters are nil
+func missingParams() error {
+	return &ValidationError{Errors: []FieldError{{Field: "params", Rule: RuleRequired, Message: "is required"}}}
+}
+
+// Validate checks the parameters before a transaction is created
+func (p *TransactionCreateParams) Validate() error {
+	if p == nil {
+		return missingParams()
+	}
+
+	v := &validator{}
+	v.required("cornerStoreId", p.CornerStoreId)
+	v.required("wholesalerTransactionId", p.WholesalerTransactionId)
+	v.positive("totalAmount", p.TotalAmount)
+	v.requiredDate("deliveryDate", p.DeliveryDate)
+	v.products("products", p.Products)
+	return v.err()
+}
+
+// Validate checks the parameters before a transaction is updated
+func (p *TransactionUpdateParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	v := &validator{}
+	if p.Status != "" && !p.Status.IsValid() {
+		statuses := make([]string, 0, len(TransactionStatuses()))
+		for _, status := range TransactionStatuses() {
+			statuses = append(statuses, string(status))
+		}
+		v.enum("status", string(p.Status), statuses...)
+	}
+	v.notNegative("transactionAmount", p.TransactionAmount)
+	if p.Latitude < -90 || p.Latitude > 90 {
+		v.add("latitude", RuleRange, "must be between -90 and 90")
+	}
+	if p.Longitude < -180 || p.Longitude > 180 {
+		v.add("longitude", RuleRange, "must be between -180 and 180")
+	}
+	return v.err()
+}
+
+// Validate checks the parameters before a transaction link is created
+func (p *TransactionLinkParams) Validate() error {
+	if p == nil {
+		return missingParams()
+	}
+
+	v := &validator{}
+	v.required("transaction.cornerStoreId", p.Transaction.CornerStoreId)
+	v.positive("transaction.totalAmount", p.Transaction.TotalAmount)
+	v.products("transaction.products", p.Transaction.Products)
+	return v.err()
+}
+
+// Validate checks the parameters before a corner store is created
+func (p *CornerStoreCreateParams) Validate() error {
+	if p == nil {
+		return missingParams()
+	}
+
+	v := &validator{}
+	v.required("name", p.Name)
+	v.required("address", p.Address)
+	v.required("city", p.City)
+	v.required("state", p.State)
+	v.required("postal_code", p.PostalCode)
+	v.required("country", p.Country)
+	v.phone("phone_number", p.PhoneNumber)
+	v.email("email", p.Email)
+	return v.err()
+}
+
+// Validate checks the parameters before a corner store is updated
+func (p *CornerStoreUpdateParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	v := &validator{}
+	v.phone("phone_number", p.PhoneNumber)
+	v.email("email", p.Email)
+	v.enum("status", p.Status, CornerStoreStatusActive, CornerStoreStatusInactive, CornerStoreStatusPending)
+	return v.err()
+}
+
+// Validate checks the parameters before a KYC verification is created
+func (p *KYCCreateParams) Validate() error {
+	if p == nil {
+		return missingParams()
+	}
+
+	v := &validator{}
+	v.required("customer_id", p.CustomerID)
+	v.required("document_type", p.DocumentType)
+	v.enum("document_type", p.DocumentType, KYCDocumentTypeID, KYCDocumentTypePassport, KYCDocumentTypeDriverLic)
+	v.required("document_id", p.DocumentID)
+	v.required("full_name", p.FullName)
+	v.dateOfBirth("date_of_birth", p.DateOfBirth)
+	return v.err()
+}
+
+// Validate checks the parameters before a KYC verification is updated
+func (p *KYCUpdateParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	v := &validator{}
+	v.enum("status", p.Status, KYCStatusPending, KYCStatusVerified, KYCStatusRejected, KYCStatusExpired)
+	v.enum("document_type", p.DocumentType, KYCDocumentTypeID, KYCDocumentTypePassport, KYCDocumentTypeDriverLic)
+	v.dateOfBirth("date_of_birth", p.DateOfBirth)
+	return v.err()
+}
+
+// Validate checks the parameters before an account is created
+func (p *AccountCreateParams) Validate() error {
+	if p == nil {
+		return missingParams()
+	}
+
+	v := &validator{}
+	v.required("customer_id", p.CustomerID)
+	v.required("name", p.Name)
+	v.required("phone_number", p.PhoneNumber)
+	v.phone("phone_number", p.PhoneNumber)
+	v.email("email", p.Email)
+	v.notNegative("credit_limit", p.CreditLimit)
+	return v.err()
+}
+
+// Validate checks the parameters before an account is updated
+func (p *AccountUpdateParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	v := &validator{}
+	v.phone("phone_number", p.PhoneNumber)
+	v.email("email", p.Email)
+	v.enum("status", p.Status, AccountStatusActive, AccountStatusInactive, AccountStatusSuspended, AccountStatusPending)
+	v.notNegative("credit_limit", p.CreditLimit)
+	return v.err()
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	delivery := NewDate(2025, time.January, 2)
+	products := []Product{{ExternalSKU: "sku-1", Quantity: 1}}
+	future := DateOf(time.Now().AddDate(1, 0, 0))
+	past := NewDate(1990, time.May, 17)
+
+	link := func(cs string, total Money, products []Product) *TransactionLinkParams {
+		p := &TransactionLinkParams{}
+		p.Transaction.CornerStoreId = cs
+		p.Transaction.TotalAmount = total
+		p.Transaction.Products = products
+		return p
+	}
+
+	tests := []struct {
+		name       string
+		params     interface{ Validate() error }
+		wantFields []string
+	}{
+		// TransactionCreateParams
+		{name: "transaction create nil", params: (*TransactionCreateParams)(nil), wantFields: []string{"params"}},
+		{
+			name:   "transaction create valid",
+			params: &TransactionCreateParams{CornerStoreId: "cs-1", WholesalerTransactionId: "order-1", TotalAmount: MXN(100), DeliveryDate: delivery, Products: products},
+		},
+		{
+			name:       "transaction create empty",
+			params:     &TransactionCreateParams{},
+			wantFields: []string{"cornerStoreId", "wholesalerTransactionId", "totalAmount", "deliveryDate", "products"},
+		},
+		{
+			name:       "transaction create missing delivery date",
+			params:     &TransactionCreateParams{CornerStoreId: "cs-1", WholesalerTransactionId: "order-1", TotalAmount: MXN(100), Products: products},
+			wantFields: []string{"deliveryDate"},
+		},
+		{
+			name:       "transaction create blank IDs",
+			params:     &TransactionCreateParams{CornerStoreId: " ", WholesalerTransactionId: "\t", TotalAmount: MXN(100), DeliveryDate: delivery, Products: products},
+			wantFields: []string{"cornerStoreId", "wholesalerTransactionId"},
+		},
+		{
+			name:       "transaction create negative total",
+			params:     &TransactionCreateParams{CornerStoreId: "cs-1", WholesalerTransactionId: "order-1", TotalAmount: MXN(-1), DeliveryDate: delivery, Products: products},
+			wantFields: []string{"totalAmount"},
+		},
+		{
+			name: "transaction create invalid products",
+			params: &TransactionCreateParams{CornerStoreId: "cs-1", WholesalerTransactionId: "order-1", TotalAmount: MXN(100), DeliveryDate: delivery,
+				Products: []Product{{Name: "ok", Quantity: 1}, {Quantity: 0}}},
+			wantFields: []string{"products[1].name", "products[1].quantity"},
+		},
+
+		// TransactionUpdateParams
+		{name: "transaction update nil", params: (*TransactionUpdateParams)(nil)},
+		{name: "transaction update empty", params: &TransactionUpdateParams{}},
+		{name: "transaction update valid", params: &TransactionUpdateParams{Status: TransactionStatusPaid, TransactionAmount: MXN(0), Latitude: -90, Longitude: 180}},
+		{name: "transaction update unknown status", params: &TransactionUpdateParams{Status: "shipped"}, wantFields: []string{"status"}},
+		{name: "transaction update negative amount", params: &TransactionUpdateParams{TransactionAmount: MXN(-5)}, wantFields: []string{"transactionAmount"}},
+		{name: "transaction update coordinates", params: &TransactionUpdateParams{Latitude: 90.5, Longitude: -180.5}, wantFields: []string{"latitude", "longitude"}},
+
+		// TransactionLinkParams
+		{name: "transaction link nil", params: (*TransactionLinkParams)(nil), wantFields: []string{"params"}},
+		{name: "transaction link valid", params: link("cs-1", MXN(100), products)},
+		{name: "transaction link empty", params: link("", Money{}, nil), wantFields: []string{"transaction.cornerStoreId", "transaction.totalAmount", "transaction.products"}},
+		{name: "transaction link invalid product", params: link("cs-1", MXN(100), []Product{{Name: "x"}}), wantFields: []string{"transaction.products[0].quantity"}},
+
+		// CornerStoreCreateParams
+		{name: "corner store create nil", params: (*CornerStoreCreateParams)(nil), wantFields: []string{"params"}},
+		{
+			name:   "corner store create valid",
+			params: &CornerStoreCreateParams{Name: "Store", Address: "Calle 1", City: "CDMX", State: "CDMX", PostalCode: "01000", Country: "MX", PhoneNumber: "55 1234 5678", Email: "store@example.com"},
+		},
+		{
+			name:       "corner store create empty",
+			params:     &CornerStoreCreateParams{},
+			wantFields: []string{"name", "address", "city", "state", "postal_code", "country"},
+		},
+		{
+			name:       "corner store create invalid contact",
+			params:     &CornerStoreCreateParams{Name: "Store", Address: "Calle 1", City: "CDMX", State: "CDMX", PostalCode: "01000", Country: "MX", PhoneNumber: "12345", Email: "Store <store@example.com>"},
+			wantFields: []string{"phone_number", "email"},
+		},
+
+		// CornerStoreUpdateParams
+		{name: "corner store update nil", params: (*CornerStoreUpdateParams)(nil)},
+		{name: "corner store update valid", params: &CornerStoreUpdateParams{PhoneNumber: "+525512345678", Status: CornerStoreStatusInactive}},
+		{name: "corner store update invalid", params: &CornerStoreUpdateParams{PhoneNumber: "phone", Email: "mail", Status: "closed"}, wantFields: []string{"phone_number", "email", "status"}},
+
+		// KYCCreateParams
+		{name: "KYC create nil", params: (*KYCCreateParams)(nil), wantFields: []string{"params"}},
+		{name: "KYC create valid", params: &KYCCreateParams{CustomerID: "user-1", DocumentType: KYCDocumentTypePassport, DocumentID: "X123", FullName: "Ana", DateOfBirth: past}},
+		{name: "KYC create empty", params: &KYCCreateParams{}, wantFields: []string{"customer_id", "document_type", "document_id", "full_name"}},
+		{
+			name:       "KYC create invalid",
+			params:     &KYCCreateParams{CustomerID: "user-1", DocumentType: "visa", DocumentID: "X123", FullName: "Ana", DateOfBirth: future},
+			wantFields: []string{"document_type", "date_of_birth"},
+		},
+
+		// KYCUpdateParams
+		{name: "KYC update nil", params: (*KYCUpdateParams)(nil)},
+		{name: "KYC update valid", params: &KYCUpdateParams{Status: KYCStatusVerified, DocumentType: KYCDocumentTypeID, DateOfBirth: past}},
+		{name: "KYC update invalid", params: &KYCUpdateParams{Status: "approved", DocumentType: "visa", DateOfBirth: future}, wantFields: []string{"status", "document_type", "date_of_birth"}},
+
+		// AccountCreateParams
+		{name: "account create nil", params: (*AccountCreateParams)(nil), wantFields: []string{"params"}},
+		{name: "account create valid", params: &AccountCreateParams{CustomerID: "user-1", Name: "Ana", PhoneNumber: "5512345678", CreditLimit: MXN(500000)}},
+		{name: "account create empty", params: &AccountCreateParams{}, wantFields: []string{"customer_id", "name", "phone_number"}},
+		{
+			name:       "account create invalid",
+			params:     &AccountCreateParams{CustomerID: "user-1", Name: "Ana", PhoneNumber: "55-1234", Email: "ana@", CreditLimit: MXN(-1)},
+			wantFields: []string{"phone_number", "email", "credit_limit"},
+		},
+
+		// AccountUpdateParams
+		{name: "account update nil", params: (*AccountUpdateParams)(nil)},
+		{name: "account update valid", params: &AccountUpdateParams{Status: AccountStatusSuspended, Email: "ana@example.com"}},
+		{name: "account update invalid", params: &AccountUpdateParams{PhoneNumber: "1", Email: "x", Status: "closed", CreditLimit: MXN(-1)}, wantFields: []string{"phone_number", "email", "status", "credit_limit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+			}
+			var fields []string
+			for _, fieldErr := range validationErr.Errors {
+				fields = append(fields, fieldErr.Field)
+			}
+			if !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("Validate() fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestValidationErrorField(t *testing.T) {
+	err := (&TransactionCreateParams{CornerStoreId: "cs-1", WholesalerTransactionId: "order-1", TotalAmount: MXN(100), Products: []Product{{Name: "x", Quantity: 1}}}).Validate()
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+	}
+
+	got := validationErr.Field("deliveryDate")
+	if len(got) != 1 || got[0].Rule != RuleRequired {
+		t.Errorf("Field(deliveryDate) = %v, want a required error", got)
+	}
+	if want := "invalid parameters: deliveryDate: is required"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
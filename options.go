@@ -0,0 +1,205 @@
+package propaga
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/diogenes-moreira/propaga-sdk/client"
+)
+
+// Environment identifies a Propaga API environment
+type Environment string
+
+const (
+	// EnvironmentProduction targets the production API
+	EnvironmentProduction Environment = "production"
+
+	// EnvironmentStaging targets the staging API
+	EnvironmentStaging Environment = "staging"
+)
+
+// BaseURL returns the base URL of the environment
+func (e Environment) BaseURL() string {
+	if e == EnvironmentStaging {
+		return client.StagingBaseURL
+	}
+	return client.DefaultBaseURL
+}
+
+// Option configures a Client created with New
+type Option func(*options)
+
+// options holds the settings collected from the Options passed to New
+type options struct {
+	environment     Environment
+	baseURL         string
+	httpClient      *http.Client
+	transport       http.RoundTripper
+	timeout         *time.Duration
+	userAgent       string
+	retryPolicy     *client.RetryPolicy
+	logger          *slog.Logger
+	logOptions      *client.LogOptions
+	instrumentation client.Instrumentation
+	rateLimiter     *client.RateLimiter
+	middlewares     []client.Middleware
+	strictDecoding  bool
+	header          http.Header
+
+	checkTransitions bool
+}
+
+// WithHTTPClient uses httpClient to perform requests instead of a new *http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to perform requests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithBaseURL overrides the base URL of the API, taking precedence over WithEnvironment
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithEnvironment selects the API environment, production by default
+func WithEnvironment(env Environment) Option {
+	return func(o *options) {
+		o.environment = env
+	}
+}
+
+// WithTimeout sets the timeout of every HTTP request, client.DefaultTimeout by default.
+// A zero timeout disables it, as for http.Client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = &timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithRetryPolicy enables retries of transient failures with the given policy
+func WithRetryPolicy(policy *client.RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithLogger sets the logger receiving a record per request
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithLogOptions controls the levels of the request records and whether redacted
+// headers and bodies are included, see client.LogOptions
+func WithLogOptions(opts client.LogOptions) Option {
+	return func(o *options) {
+		o.logOptions = &opts
+	}
+}
+
+// WithInstrumentation observes every API call with inst, e.g. an otelpropaga.Instrumentation
+func WithInstrumentation(inst client.Instrumentation) Option {
+	return func(o *options) {
+		o.instrumentation = inst
+	}
+}
+
+// WithRateLimit throttles the requests of the client with a token-bucket limiter
+// shared by all its services, see client.RateLimit
+func WithRateLimit(limit client.RateLimit) Option {
+	return func(o *options) {
+		o.rateLimiter = client.NewRateLimiter(limit)
+	}
+}
+
+// WithRateLimiter throttles the requests of the client with limiter,
+// which may be shared with other clients using the same API key
+func WithRateLimiter(limiter *client.RateLimiter) Option {
+	return func(o *options) {
+		o.rateLimiter = limiter
+	}
+}
+
+// WithMiddleware registers middlewares intercepting every request of the client,
+// the first one being the outermost
+func WithMiddleware(middlewares ...client.Middleware) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// WithStrictDecoding makes responses holding fields unknown to the models fail instead of
+// keeping them in their Extra, to detect changes of the API in contract tests
+func WithStrictDecoding() Option {
+	return func(o *options) {
+		o.strictDecoding = true
+	}
+}
+
+// WithTransitionChecks makes Transactions.Update and Transactions.Cancel fetch the transaction
+// and refuse illegal status transitions before calling the API, see transactions.Service.CheckTransitions
+func WithTransitionChecks() Option {
+	return func(o *options) {
+		o.checkTransitions = true
+	}
+}
+
+// WithHeader adds a default header sent with every request
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithDefaultHeaders adds default headers sent with every request
+func WithDefaultHeaders(header http.Header) Option {
+	return func(o *options) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		for key, values := range header {
+			for _, value := range values {
+				o.header.Add(key, value)
+			}
+		}
+	}
+}
+
+// buildHTTPClient builds the HTTP client from the collected options.
+// A client supplied with WithHTTPClient is copied so that it is never modified.
+func (o *options) buildHTTPClient() *http.Client {
+	httpClient := &http.Client{Timeout: client.DefaultTimeout}
+	if o.httpClient != nil {
+		copied := *o.httpClient
+		httpClient = &copied
+	}
+	if o.timeout != nil {
+		httpClient.Timeout = *o.timeout
+	}
+	if o.transport != nil {
+		httpClient.Transport = o.transport
+	}
+
+	return httpClient
+}
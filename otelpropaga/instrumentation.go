@@ -0,0 +1,172 @@
+package otelpropaga
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/diogenes-moreira/propaga-sdk/client"
+)
+
+// ScopeName is the instrumentation scope of the tracer and the meter
+const ScopeName = "github.com/diogenes-moreira/propaga-sdk/otelpropaga"
+
+// Names of the recorded metrics
+const (
+	MetricRequestDuration = "propaga.client.request.duration"
+	MetricRequestErrors   = "propaga.client.request.errors"
+)
+
+// Attribute keys set on spans and metrics, along with the client.Attribute... keys
+const (
+	AttributeService   = attribute.Key("propaga.service")
+	AttributeOperation = attribute.Key("propaga.operation")
+	AttributeAttempts  = attribute.Key("propaga.attempts")
+	AttributeMethod    = attribute.Key("http.request.method")
+	AttributeStatus    = attribute.Key("http.response.status_code")
+	AttributePath      = attribute.Key("url.path")
+)
+
+// Instrumentation is a client.Instrumentation creating an OpenTelemetry span per API call,
+// propagating the trace context headers, and recording the latency and the errors per endpoint.
+//
+//	inst, err := otelpropaga.New()
+//	c := propaga.New(apiKey, propaga.WithInstrumentation(inst))
+type Instrumentation struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   metric.Float64Histogram
+	errors     metric.Int64Counter
+}
+
+var _ client.Instrumentation = (*Instrumentation)(nil)
+
+// config holds the settings collected from the Options
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option configures an Instrumentation
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, the global one by default
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider, the global one by default
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagator sets the propagator injecting the trace context headers, the global one by default
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// New creates an OpenTelemetry instrumentation for the Propaga client
+func New(opts ...Option) (*Instrumentation, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.tracerProvider == nil {
+		cfg.tracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.meterProvider == nil {
+		cfg.meterProvider = otel.GetMeterProvider()
+	}
+	if cfg.propagator == nil {
+		cfg.propagator = otel.GetTextMapPropagator()
+	}
+
+	meter := cfg.meterProvider.Meter(ScopeName)
+	duration, err := meter.Float64Histogram(MetricRequestDuration,
+		metric.WithDescription("Duration of the Propaga API calls, including retries"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s histogram: %w", MetricRequestDuration, err)
+	}
+
+	errors, err := meter.Int64Counter(MetricRequestErrors,
+		metric.WithDescription("Number of failed Propaga API calls"),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s counter: %w", MetricRequestErrors, err)
+	}
+
+	return &Instrumentation{
+		tracer:     cfg.tracerProvider.Tracer(ScopeName),
+		propagator: cfg.propagator,
+		duration:   duration,
+		errors:     errors,
+	}, nil
+}
+
+// StartCall implements client.Instrumentation
+func (i *Instrumentation) StartCall(ctx context.Context, info *client.RequestInfo) (context.Context, func(client.CallResult)) {
+	endpoint := []attribute.KeyValue{
+		AttributeService.String(info.Service),
+		AttributeOperation.String(info.Operation),
+		AttributeMethod.String(info.Method),
+	}
+
+	spanAttrs := append([]attribute.KeyValue{AttributePath.String(info.Path)}, endpoint...)
+	for key, value := range info.Attributes {
+		spanAttrs = append(spanAttrs, attribute.String(key, value))
+	}
+
+	ctx, span := i.tracer.Start(ctx, spanName(info),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(spanAttrs...))
+	start := time.Now()
+
+	return ctx, func(result client.CallResult) {
+		attrs := endpoint
+		if result.StatusCode != 0 {
+			attrs = append(attrs, AttributeStatus.Int(result.StatusCode))
+		}
+
+		span.SetAttributes(AttributeAttempts.Int(result.Attempts))
+		if result.StatusCode != 0 {
+			span.SetAttributes(AttributeStatus.Int(result.StatusCode))
+		}
+		if result.Err != nil {
+			span.RecordError(result.Err)
+			span.SetStatus(codes.Error, result.Err.Error())
+			i.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+		span.End()
+
+		i.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	}
+}
+
+// InjectHeaders implements client.Instrumentation
+func (i *Instrumentation) InjectHeaders(ctx context.Context, header http.Header) {
+	i.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// spanName returns the name of the span of a call, such as "propaga.transactions.Get"
+func spanName(info *client.RequestInfo) string {
+	if info.Service == "" || info.Operation == "" {
+		return fmt.Sprintf("propaga %s", info.Method)
+	}
+	return fmt.Sprintf("propaga.%s.%s", info.Service, info.Operation)
+}
@@ -0,0 +1,146 @@
+package otelpropaga_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+	"github.com/diogenes-moreira/propaga-sdk/otelpropaga"
+	"github.com/diogenes-moreira/propaga-sdk/propagatest"
+)
+
+// setup returns a fake server and a client instrumented with in-memory exporters
+func setup(t *testing.T) (*propagatest.Server, *propaga.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	inst, err := otelpropaga.New(
+		otelpropaga.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		otelpropaga.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		otelpropaga.WithPropagator(propagation.TraceContext{}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	server := propagatest.NewServer()
+	t.Cleanup(server.Close)
+
+	return server, server.Client(propaga.WithInstrumentation(inst)), spans, reader
+}
+
+func TestSpanAttributes(t *testing.T) {
+	server, c, spans, _ := setup(t)
+	tx := server.AddTransaction(models.Transaction{})
+
+	if _, err := c.Transactions.Get(tx.TransactionId); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans, want 1", len(ended))
+	}
+	span := ended[0]
+	if span.Name() != "propaga.transactions.Get" || span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span %q of kind %v, want propaga.transactions.Get of kind client", span.Name(), span.SpanKind())
+	}
+	if span.Status().Code == codes.Error {
+		t.Errorf("span status = %v, want unset", span.Status())
+	}
+
+	attrs := attribute.NewSet(span.Attributes()...)
+	want := map[attribute.Key]attribute.Value{
+		otelpropaga.AttributeService:                 attribute.StringValue("transactions"),
+		otelpropaga.AttributeOperation:               attribute.StringValue("Get"),
+		otelpropaga.AttributeMethod:                  attribute.StringValue("GET"),
+		otelpropaga.AttributePath:                    attribute.StringValue("/v1/transaction/" + tx.TransactionId),
+		otelpropaga.AttributeStatus:                  attribute.IntValue(200),
+		otelpropaga.AttributeAttempts:                attribute.IntValue(1),
+		attribute.Key(client.AttributeTransactionID): attribute.StringValue(tx.TransactionId),
+	}
+	for key, value := range want {
+		if got, ok := attrs.Value(key); !ok || got != value {
+			t.Errorf("attribute %s = %v, want %v", key, got.Emit(), value.Emit())
+		}
+	}
+}
+
+func TestTraceHeaderInjection(t *testing.T) {
+	server, c, spans, _ := setup(t)
+
+	if _, err := c.Transactions.ListContext(context.Background(), nil); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	carrier := propagation.HeaderCarrier(requests[0].Header)
+	sent := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), carrier))
+	if !sent.IsValid() {
+		t.Fatalf("no traceparent header in %v", requests[0].Header)
+	}
+
+	span := spans.Ended()[0].SpanContext()
+	if sent.TraceID() != span.TraceID() || sent.SpanID() != span.SpanID() {
+		t.Errorf("propagated %s/%s, want the call span %s/%s", sent.TraceID(), sent.SpanID(), span.TraceID(), span.SpanID())
+	}
+}
+
+func TestErrorCounter(t *testing.T) {
+	_, c, spans, reader := setup(t)
+
+	if _, err := c.Transactions.Get("missing"); !client.IsNotFound(err) {
+		t.Fatalf("Get() error = %v, want not found", err)
+	}
+	if _, err := c.Transactions.Get("missing"); !client.IsNotFound(err) {
+		t.Fatalf("Get() error = %v, want not found", err)
+	}
+
+	for _, span := range spans.Ended() {
+		if span.Status().Code != codes.Error || len(span.Events()) == 0 {
+			t.Errorf("span status = %v with %d events, want an error and its event", span.Status(), len(span.Events()))
+		}
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	var errorCount, durationCount int64
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch m.Name {
+			case otelpropaga.MetricRequestErrors:
+				for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+					errorCount += point.Value
+					if status, _ := point.Attributes.Value(otelpropaga.AttributeStatus); status.AsInt64() != 404 {
+						t.Errorf("error point status = %v, want 404", status.Emit())
+					}
+				}
+			case otelpropaga.MetricRequestDuration:
+				for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+					durationCount += int64(point.Count)
+				}
+			}
+		}
+	}
+	if errorCount != 2 || durationCount != 2 {
+		t.Errorf("recorded %d errors and %d durations, want 2 of each", errorCount, durationCount)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/diogenes-moreira/propaga-sdk/kyc"
 	"time"
 
+	"github.com/diogenes-moreira/propaga-sdk/account"
 	"github.com/diogenes-moreira/propaga-sdk/auth"
 	"github.com/diogenes-moreira/propaga-sdk/client"
 	"github.com/diogenes-moreira/propaga-sdk/transactions"
@@ -15,23 +16,67 @@ type Client struct {
 	// Underlying HTTP client
 	httpClient *client.Client
 
-	// Available services
-	Auth         *auth.Service
-	Transactions *transactions.Service
-	CornerStores *cornerstore.Service
-	KYC          *kyc.Service
+	// Available services, declared as interfaces so that they can be replaced by fakes
+	// such as the ones of the propagamock package
+	Auth         AuthAPI
+	Transactions TransactionsAPI
+	CornerStores CornerStoresAPI
+	KYC          KYCAPI
+	Accounts     AccountsAPI
+}
+
+// New creates a new instance of the Propaga client configured with opts.
+// Without options it targets the production API with the default timeout.
+func New(apiKey string, opts ...Option) *Client {
+	o := &options{
+		environment: EnvironmentProduction,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	baseURL := o.baseURL
+	if baseURL == "" {
+		baseURL = o.environment.BaseURL()
+	}
+
+	httpClient := &client.Client{
+		BaseURL:         baseURL,
+		HTTPClient:      o.buildHTTPClient(),
+		APIKey:          apiKey,
+		RetryPolicy:     o.retryPolicy,
+		UserAgent:       o.userAgent,
+		Header:          o.header,
+		Logger:          o.logger,
+		LogOptions:      o.logOptions,
+		Instrumentation: o.instrumentation,
+		RateLimiter:     o.rateLimiter,
+		Middlewares:     o.middlewares,
+		StrictDecoding:  o.strictDecoding,
+	}
+
+	c := newClientWithHTTPClient(httpClient)
+	if o.checkTransitions {
+		service := transactions.NewService(httpClient)
+		service.CheckTransitions = true
+		c.Transactions = service
+	}
+
+	return c
 }
 
 // NewClient creates a new instance of the Propaga client with default configuration
 func NewClient(apiKey string, staging bool) *Client {
-	httpClient := client.NewClient(apiKey, staging)
-	return newClientWithHTTPClient(httpClient)
+	env := EnvironmentProduction
+	if staging {
+		env = EnvironmentStaging
+	}
+	return New(apiKey, WithEnvironment(env))
 }
 
 // NewClientWithOptions creates a new instance of the client with custom options
 func NewClientWithOptions(apiKey, baseURL string, timeout time.Duration) *Client {
-	httpClient := client.NewClientWithOptions(apiKey, baseURL, timeout)
-	return newClientWithHTTPClient(httpClient)
+	return New(apiKey, WithBaseURL(baseURL), WithTimeout(timeout))
 }
 
 // Helper function to create a client with an existing HTTP client
@@ -45,5 +90,6 @@ func newClientWithHTTPClient(httpClient *client.Client) *Client {
 	c.Transactions = transactions.NewService(httpClient)
 	c.CornerStores = cornerstore.NewService(httpClient)
 	c.KYC = kyc.NewService(httpClient)
+	c.Accounts = account.NewService(httpClient)
 	return c
 }
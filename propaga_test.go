@@ -0,0 +1,54 @@
+package propaga
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/diogenes-moreira/propaga-sdk/client"
+)
+
+func TestConstructorsWireEveryService(t *testing.T) {
+	constructors := map[string]func() *Client{
+		"New":                  func() *Client { return New("key") },
+		"NewClient":            func() *Client { return NewClient("key", true) },
+		"NewClientWithOptions": func() *Client { return NewClientWithOptions("key", "http://localhost", time.Second) },
+	}
+
+	for name, newClient := range constructors {
+		t.Run(name, func(t *testing.T) {
+			c := reflect.ValueOf(newClient()).Elem()
+			for i := 0; i < c.NumField(); i++ {
+				field := c.Type().Field(i)
+				if !field.IsExported() {
+					continue
+				}
+				if c.Field(i).IsNil() {
+					t.Errorf("%s leaves service %s unwired", name, field.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestNewBaseURL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", want: client.DefaultBaseURL},
+		{name: "staging", opts: []Option{WithEnvironment(EnvironmentStaging)}, want: client.StagingBaseURL},
+		{name: "base URL", opts: []Option{WithBaseURL("http://localhost")}, want: "http://localhost"},
+		{name: "base URL before environment", opts: []Option{WithBaseURL("http://localhost"), WithEnvironment(EnvironmentStaging)}, want: "http://localhost"},
+		{name: "base URL after environment", opts: []Option{WithEnvironment(EnvironmentStaging), WithBaseURL("http://localhost")}, want: "http://localhost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New("key", tt.opts...).httpClient.BaseURL; got != tt.want {
+				t.Errorf("BaseURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
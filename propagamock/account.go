@@ -0,0 +1,128 @@
+package propagamock
+
+import (
+	"context"
+	"iter"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// Accounts is a recording fake of propaga.AccountsAPI.
+// Each method records its call and delegates to the matching ...Func field.
+type Accounts struct {
+	Recorder
+
+	ListFunc     func(ctx context.Context, params *models.AccountListParams) (*models.AccountListResponse, error)
+	GetFunc      func(ctx context.Context, id string) (*models.Account, error)
+	CreateFunc   func(ctx context.Context, params *models.AccountCreateParams) (*models.Account, error)
+	UpdateFunc   func(ctx context.Context, id string, params *models.AccountUpdateParams) (*models.Account, error)
+	SuspendFunc  func(ctx context.Context, id string) (*models.Account, error)
+	ActivateFunc func(ctx context.Context, id string) (*models.Account, error)
+}
+
+var _ propaga.AccountsAPI = (*Accounts)(nil)
+
+// List implements propaga.AccountsAPI
+func (f *Accounts) List(params *models.AccountListParams) (*models.AccountListResponse, error) {
+	return f.ListContext(context.Background(), params)
+}
+
+// ListContext implements propaga.AccountsAPI
+func (f *Accounts) ListContext(ctx context.Context, params *models.AccountListParams) (*models.AccountListResponse, error) {
+	f.record("List", params)
+	if f.ListFunc == nil {
+		return nil, notImplemented("Accounts.List")
+	}
+	return f.ListFunc(ctx, params)
+}
+
+// Get implements propaga.AccountsAPI
+func (f *Accounts) Get(id string) (*models.Account, error) {
+	return f.GetContext(context.Background(), id)
+}
+
+// GetContext implements propaga.AccountsAPI
+func (f *Accounts) GetContext(ctx context.Context, id string) (*models.Account, error) {
+	f.record("Get", id)
+	if f.GetFunc == nil {
+		return nil, notImplemented("Accounts.Get")
+	}
+	return f.GetFunc(ctx, id)
+}
+
+// Create implements propaga.AccountsAPI
+func (f *Accounts) Create(params *models.AccountCreateParams) (*models.Account, error) {
+	return f.CreateContext(context.Background(), params)
+}
+
+// CreateContext implements propaga.AccountsAPI
+func (f *Accounts) CreateContext(ctx context.Context, params *models.AccountCreateParams) (*models.Account, error) {
+	f.record("Create", params)
+	if f.CreateFunc == nil {
+		return nil, notImplemented("Accounts.Create")
+	}
+	return f.CreateFunc(ctx, params)
+}
+
+// Update implements propaga.AccountsAPI
+func (f *Accounts) Update(id string, params *models.AccountUpdateParams) (*models.Account, error) {
+	return f.UpdateContext(context.Background(), id, params)
+}
+
+// UpdateContext implements propaga.AccountsAPI
+func (f *Accounts) UpdateContext(ctx context.Context, id string, params *models.AccountUpdateParams) (*models.Account, error) {
+	f.record("Update", id, params)
+	if f.UpdateFunc == nil {
+		return nil, notImplemented("Accounts.Update")
+	}
+	return f.UpdateFunc(ctx, id, params)
+}
+
+// Suspend implements propaga.AccountsAPI
+func (f *Accounts) Suspend(id string) (*models.Account, error) {
+	return f.SuspendContext(context.Background(), id)
+}
+
+// SuspendContext implements propaga.AccountsAPI
+func (f *Accounts) SuspendContext(ctx context.Context, id string) (*models.Account, error) {
+	f.record("Suspend", id)
+	if f.SuspendFunc == nil {
+		return nil, notImplemented("Accounts.Suspend")
+	}
+	return f.SuspendFunc(ctx, id)
+}
+
+// Activate implements propaga.AccountsAPI
+func (f *Accounts) Activate(id string) (*models.Account, error) {
+	return f.ActivateContext(context.Background(), id)
+}
+
+// ActivateContext implements propaga.AccountsAPI
+func (f *Accounts) ActivateContext(ctx context.Context, id string) (*models.Account, error) {
+	f.record("Activate", id)
+	if f.ActivateFunc == nil {
+		return nil, notImplemented("Accounts.Activate")
+	}
+	return f.ActivateFunc(ctx, id)
+}
+
+// ListAll implements propaga.AccountsAPI on top of ListContext
+func (f *Accounts) ListAll(ctx context.Context, params *models.AccountListParams, opts *client.IteratorOptions) iter.Seq2[models.Account, error] {
+	return f.ListIterator(ctx, params, opts).All()
+}
+
+// ListIterator implements propaga.AccountsAPI on top of ListContext
+func (f *Accounts) ListIterator(ctx context.Context, params *models.AccountListParams, opts *client.IteratorOptions) *client.Iterator[models.Account] {
+	pageFields := func(p *models.AccountListParams) (*int, *int) { return &p.Limit, &p.Offset }
+	list := func(ctx context.Context, page *models.AccountListParams) ([]models.Account, int, error) {
+		result, err := f.ListContext(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data, result.TotalCount, nil
+	}
+
+	return client.ListIterator(ctx, params, pageFields, list, opts)
+}
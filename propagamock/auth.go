@@ -0,0 +1,31 @@
+package propagamock
+
+import (
+	"context"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+)
+
+// Auth is a recording fake of propaga.AuthAPI.
+// Each method records its call and delegates to the matching ...Func field.
+type Auth struct {
+	Recorder
+
+	ValidateTokenFunc func(ctx context.Context) (bool, error)
+}
+
+var _ propaga.AuthAPI = (*Auth)(nil)
+
+// ValidateToken implements propaga.AuthAPI
+func (f *Auth) ValidateToken() (bool, error) {
+	return f.ValidateTokenContext(context.Background())
+}
+
+// ValidateTokenContext implements propaga.AuthAPI
+func (f *Auth) ValidateTokenContext(ctx context.Context) (bool, error) {
+	f.record("ValidateToken")
+	if f.ValidateTokenFunc == nil {
+		return false, notImplemented("Auth.ValidateToken")
+	}
+	return f.ValidateTokenFunc(ctx)
+}
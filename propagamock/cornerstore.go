@@ -0,0 +1,128 @@
+package propagamock
+
+import (
+	"context"
+	"iter"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// CornerStores is a recording fake of propaga.CornerStoresAPI.
+// Each method records its call and delegates to the matching ...Func field.
+type CornerStores struct {
+	Recorder
+
+	ListFunc                           func(ctx context.Context, params *models.CornerStoreListParams) (*models.CornerStoreListResponse, error)
+	GetFunc                            func(ctx context.Context, id string) (*models.CornerStore, error)
+	CreateFunc                         func(ctx context.Context, params *models.CornerStoreCreateParams) (*models.CornerStore, error)
+	UpdateFunc                         func(ctx context.Context, id string, params *models.CornerStoreUpdateParams) (*models.CornerStore, error)
+	DeleteFunc                         func(ctx context.Context, id string) error
+	GetCornerStoreInfoByExternalIdFunc func(ctx context.Context, id int) (*models.CornerStoreInfo, error)
+}
+
+var _ propaga.CornerStoresAPI = (*CornerStores)(nil)
+
+// List implements propaga.CornerStoresAPI
+func (f *CornerStores) List(params *models.CornerStoreListParams) (*models.CornerStoreListResponse, error) {
+	return f.ListContext(context.Background(), params)
+}
+
+// ListContext implements propaga.CornerStoresAPI
+func (f *CornerStores) ListContext(ctx context.Context, params *models.CornerStoreListParams) (*models.CornerStoreListResponse, error) {
+	f.record("List", params)
+	if f.ListFunc == nil {
+		return nil, notImplemented("CornerStores.List")
+	}
+	return f.ListFunc(ctx, params)
+}
+
+// Get implements propaga.CornerStoresAPI
+func (f *CornerStores) Get(id string) (*models.CornerStore, error) {
+	return f.GetContext(context.Background(), id)
+}
+
+// GetContext implements propaga.CornerStoresAPI
+func (f *CornerStores) GetContext(ctx context.Context, id string) (*models.CornerStore, error) {
+	f.record("Get", id)
+	if f.GetFunc == nil {
+		return nil, notImplemented("CornerStores.Get")
+	}
+	return f.GetFunc(ctx, id)
+}
+
+// Create implements propaga.CornerStoresAPI
+func (f *CornerStores) Create(params *models.CornerStoreCreateParams) (*models.CornerStore, error) {
+	return f.CreateContext(context.Background(), params)
+}
+
+// CreateContext implements propaga.CornerStoresAPI
+func (f *CornerStores) CreateContext(ctx context.Context, params *models.CornerStoreCreateParams) (*models.CornerStore, error) {
+	f.record("Create", params)
+	if f.CreateFunc == nil {
+		return nil, notImplemented("CornerStores.Create")
+	}
+	return f.CreateFunc(ctx, params)
+}
+
+// Update implements propaga.CornerStoresAPI
+func (f *CornerStores) Update(id string, params *models.CornerStoreUpdateParams) (*models.CornerStore, error) {
+	return f.UpdateContext(context.Background(), id, params)
+}
+
+// UpdateContext implements propaga.CornerStoresAPI
+func (f *CornerStores) UpdateContext(ctx context.Context, id string, params *models.CornerStoreUpdateParams) (*models.CornerStore, error) {
+	f.record("Update", id, params)
+	if f.UpdateFunc == nil {
+		return nil, notImplemented("CornerStores.Update")
+	}
+	return f.UpdateFunc(ctx, id, params)
+}
+
+// Delete implements propaga.CornerStoresAPI
+func (f *CornerStores) Delete(id string) error {
+	return f.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext implements propaga.CornerStoresAPI
+func (f *CornerStores) DeleteContext(ctx context.Context, id string) error {
+	f.record("Delete", id)
+	if f.DeleteFunc == nil {
+		return notImplemented("CornerStores.Delete")
+	}
+	return f.DeleteFunc(ctx, id)
+}
+
+// GetCornerStoreInfoByExternalId implements propaga.CornerStoresAPI
+func (f *CornerStores) GetCornerStoreInfoByExternalId(id int) (*models.CornerStoreInfo, error) {
+	return f.GetCornerStoreInfoByExternalIdContext(context.Background(), id)
+}
+
+// GetCornerStoreInfoByExternalIdContext implements propaga.CornerStoresAPI
+func (f *CornerStores) GetCornerStoreInfoByExternalIdContext(ctx context.Context, id int) (*models.CornerStoreInfo, error) {
+	f.record("GetCornerStoreInfoByExternalId", id)
+	if f.GetCornerStoreInfoByExternalIdFunc == nil {
+		return nil, notImplemented("CornerStores.GetCornerStoreInfoByExternalId")
+	}
+	return f.GetCornerStoreInfoByExternalIdFunc(ctx, id)
+}
+
+// ListAll implements propaga.CornerStoresAPI on top of ListContext
+func (f *CornerStores) ListAll(ctx context.Context, params *models.CornerStoreListParams, opts *client.IteratorOptions) iter.Seq2[models.CornerStore, error] {
+	return f.ListIterator(ctx, params, opts).All()
+}
+
+// ListIterator implements propaga.CornerStoresAPI on top of ListContext
+func (f *CornerStores) ListIterator(ctx context.Context, params *models.CornerStoreListParams, opts *client.IteratorOptions) *client.Iterator[models.CornerStore] {
+	pageFields := func(p *models.CornerStoreListParams) (*int, *int) { return &p.Limit, &p.Offset }
+	list := func(ctx context.Context, page *models.CornerStoreListParams) ([]models.CornerStore, int, error) {
+		result, err := f.ListContext(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data, result.TotalCount, nil
+	}
+
+	return client.ListIterator(ctx, params, pageFields, list, opts)
+}
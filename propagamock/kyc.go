@@ -0,0 +1,128 @@
+package propagamock
+
+import (
+	"context"
+	"iter"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// KYC is a recording fake of propaga.KYCAPI.
+// Each method records its call and delegates to the matching ...Func field.
+type KYC struct {
+	Recorder
+
+	ListFunc   func(ctx context.Context, params *models.KYCListParams) (*models.KYCListResponse, error)
+	GetFunc    func(ctx context.Context, id string) (*models.KYC, error)
+	CreateFunc func(ctx context.Context, params *models.KYCCreateParams) (*models.KYC, error)
+	UpdateFunc func(ctx context.Context, id string, params *models.KYCUpdateParams) (*models.KYC, error)
+	VerifyFunc func(ctx context.Context, id string) (*models.KYC, error)
+	RejectFunc func(ctx context.Context, id string, reason string) (*models.KYC, error)
+}
+
+var _ propaga.KYCAPI = (*KYC)(nil)
+
+// List implements propaga.KYCAPI
+func (f *KYC) List(params *models.KYCListParams) (*models.KYCListResponse, error) {
+	return f.ListContext(context.Background(), params)
+}
+
+// ListContext implements propaga.KYCAPI
+func (f *KYC) ListContext(ctx context.Context, params *models.KYCListParams) (*models.KYCListResponse, error) {
+	f.record("List", params)
+	if f.ListFunc == nil {
+		return nil, notImplemented("KYC.List")
+	}
+	return f.ListFunc(ctx, params)
+}
+
+// Get implements propaga.KYCAPI
+func (f *KYC) Get(id string) (*models.KYC, error) {
+	return f.GetContext(context.Background(), id)
+}
+
+// GetContext implements propaga.KYCAPI
+func (f *KYC) GetContext(ctx context.Context, id string) (*models.KYC, error) {
+	f.record("Get", id)
+	if f.GetFunc == nil {
+		return nil, notImplemented("KYC.Get")
+	}
+	return f.GetFunc(ctx, id)
+}
+
+// Create implements propaga.KYCAPI
+func (f *KYC) Create(params *models.KYCCreateParams) (*models.KYC, error) {
+	return f.CreateContext(context.Background(), params)
+}
+
+// CreateContext implements propaga.KYCAPI
+func (f *KYC) CreateContext(ctx context.Context, params *models.KYCCreateParams) (*models.KYC, error) {
+	f.record("Create", params)
+	if f.CreateFunc == nil {
+		return nil, notImplemented("KYC.Create")
+	}
+	return f.CreateFunc(ctx, params)
+}
+
+// Update implements propaga.KYCAPI
+func (f *KYC) Update(id string, params *models.KYCUpdateParams) (*models.KYC, error) {
+	return f.UpdateContext(context.Background(), id, params)
+}
+
+// UpdateContext implements propaga.KYCAPI
+func (f *KYC) UpdateContext(ctx context.Context, id string, params *models.KYCUpdateParams) (*models.KYC, error) {
+	f.record("Update", id, params)
+	if f.UpdateFunc == nil {
+		return nil, notImplemented("KYC.Update")
+	}
+	return f.UpdateFunc(ctx, id, params)
+}
+
+// Verify implements propaga.KYCAPI
+func (f *KYC) Verify(id string) (*models.KYC, error) {
+	return f.VerifyContext(context.Background(), id)
+}
+
+// VerifyContext implements propaga.KYCAPI
+func (f *KYC) VerifyContext(ctx context.Context, id string) (*models.KYC, error) {
+	f.record("Verify", id)
+	if f.VerifyFunc == nil {
+		return nil, notImplemented("KYC.Verify")
+	}
+	return f.VerifyFunc(ctx, id)
+}
+
+// Reject implements propaga.KYCAPI
+func (f *KYC) Reject(id string, reason string) (*models.KYC, error) {
+	return f.RejectContext(context.Background(), id, reason)
+}
+
+// RejectContext implements propaga.KYCAPI
+func (f *KYC) RejectContext(ctx context.Context, id string, reason string) (*models.KYC, error) {
+	f.record("Reject", id, reason)
+	if f.RejectFunc == nil {
+		return nil, notImplemented("KYC.Reject")
+	}
+	return f.RejectFunc(ctx, id, reason)
+}
+
+// ListAll implements propaga.KYCAPI on top of ListContext
+func (f *KYC) ListAll(ctx context.Context, params *models.KYCListParams, opts *client.IteratorOptions) iter.Seq2[models.KYC, error] {
+	return f.ListIterator(ctx, params, opts).All()
+}
+
+// ListIterator implements propaga.KYCAPI on top of ListContext
+func (f *KYC) ListIterator(ctx context.Context, params *models.KYCListParams, opts *client.IteratorOptions) *client.Iterator[models.KYC] {
+	pageFields := func(p *models.KYCListParams) (*int, *int) { return &p.Limit, &p.Offset }
+	list := func(ctx context.Context, page *models.KYCListParams) ([]models.KYC, int, error) {
+		result, err := f.ListContext(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data, result.TotalCount, nil
+	}
+
+	return client.ListIterator(ctx, params, pageFields, list, opts)
+}
@@ -0,0 +1,189 @@
+package propagamock_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+	"github.com/diogenes-moreira/propaga-sdk/propagamock"
+	"github.com/diogenes-moreira/propaga-sdk/transactions"
+)
+
+func TestRecordsCalls(t *testing.T) {
+	fake := &propagamock.Transactions{
+		GetFunc: func(ctx context.Context, id string) (*models.Transaction, error) {
+			return &models.Transaction{TransactionId: id}, nil
+		},
+	}
+
+	// Both variants of a method are recorded under the name without the Context suffix
+	tx, err := fake.Get("txn_1")
+	if err != nil || tx.TransactionId != "txn_1" {
+		t.Fatalf("Get() = %v, %v", tx, err)
+	}
+	if _, err := fake.GetContext(context.Background(), "txn_2"); err != nil {
+		t.Fatalf("GetContext() error = %v", err)
+	}
+	if _, err := fake.Cancel("txn_3"); err == nil {
+		t.Fatalf("Cancel() without CancelFunc succeeded")
+	}
+
+	want := []propagamock.Call{
+		{Method: "Get", Args: []interface{}{"txn_1"}},
+		{Method: "Get", Args: []interface{}{"txn_2"}},
+		{Method: "Cancel", Args: []interface{}{"txn_3"}},
+	}
+	if got := fake.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %v, want %v", got, want)
+	}
+	if got := fake.CallsTo("Get"); len(got) != 2 {
+		t.Errorf("CallsTo(Get) = %v, want 2 calls", got)
+	}
+
+	fake.Reset()
+	if got := fake.Calls(); len(got) != 0 {
+		t.Errorf("Calls() after Reset() = %v", got)
+	}
+}
+
+func TestNotImplemented(t *testing.T) {
+	calls := []struct {
+		name string
+		call func() error
+	}{
+		{name: "Auth.ValidateToken", call: func() error { _, err := (&propagamock.Auth{}).ValidateToken(); return err }},
+		{name: "Transactions.Create", call: func() error { _, err := (&propagamock.Transactions{}).Create(nil); return err }},
+		{name: "CornerStores.Delete", call: func() error { return (&propagamock.CornerStores{}).Delete("cs_1") }},
+		{name: "KYC.Verify", call: func() error { _, err := (&propagamock.KYC{}).Verify("kyc_1"); return err }},
+		{name: "Accounts.Suspend", call: func() error { _, err := (&propagamock.Accounts{}).Suspend("acc_1"); return err }},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.Is(err, propagamock.ErrNotImplemented) {
+				t.Errorf("error = %v, want ErrNotImplemented", err)
+			}
+		})
+	}
+}
+
+func TestFakesReplaceServices(t *testing.T) {
+	fake := &propagamock.KYC{
+		ListFunc: func(ctx context.Context, params *models.KYCListParams) (*models.KYCListResponse, error) {
+			return &models.KYCListResponse{Data: []models.KYC{{ID: "kyc_1"}}, TotalCount: 1}, nil
+		},
+	}
+	api := propaga.New("key")
+	api.KYC = fake
+
+	var lister propaga.KYCLister = api.KYC
+	for kyc, err := range lister.ListAll(context.Background(), nil, nil) {
+		if err != nil || kyc.ID != "kyc_1" {
+			t.Errorf("ListAll() yielded %v, %v", kyc, err)
+		}
+	}
+	if got := fake.CallsTo("List"); len(got) != 1 {
+		t.Errorf("CallsTo(List) = %v, want 1 call", got)
+	}
+}
+
+func TestListAllPages(t *testing.T) {
+	fake := &propagamock.Transactions{
+		ListFunc: func(ctx context.Context, params *models.TransactionListParams) (*models.TransactionListResponse, error) {
+			var data []models.Transaction
+			for i := params.Offset; i < min(params.Offset+params.Limit, 5); i++ {
+				data = append(data, models.Transaction{TransactionId: fmt.Sprintf("txn_%d", i)})
+			}
+			return &models.TransactionListResponse{Data: data, TotalCount: 5}, nil
+		},
+	}
+
+	var ids []string
+	for tx, err := range fake.ListAll(context.Background(), &models.TransactionListParams{Limit: 2}, nil) {
+		if err != nil {
+			t.Fatalf("ListAll() error = %v", err)
+		}
+		ids = append(ids, tx.TransactionId)
+	}
+
+	if len(ids) != 5 || len(fake.CallsTo("List")) != 3 {
+		t.Errorf("got %v in %d pages, want 5 transactions in 3 pages", ids, len(fake.CallsTo("List")))
+	}
+}
+
+func TestCreateBatch(t *testing.T) {
+	fake := &propagamock.Transactions{
+		CreateFunc: func(ctx context.Context, params *models.TransactionCreateParams) (*models.Transaction, error) {
+			if params.WholesalerTransactionId == "bad" {
+				return nil, errors.New("refused")
+			}
+			return &models.Transaction{WholesalerTransactionId: params.WholesalerTransactionId}, nil
+		},
+	}
+
+	params := []*models.TransactionCreateParams{{WholesalerTransactionId: "a"}, {WholesalerTransactionId: "bad"}, {WholesalerTransactionId: "c"}}
+	result, err := fake.CreateBatch(context.Background(), params, &transactions.BatchOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+
+	if result.Succeeded != 2 || result.Failed != 1 || result.Items[1].Err == nil {
+		t.Errorf("CreateBatch() = %+v, want the second item failed", result)
+	}
+	if got := fake.CallsTo("CreateBatch"); len(got) != 1 {
+		t.Errorf("CallsTo(CreateBatch) = %v, want 1 call", got)
+	}
+	if got := fake.CallsTo("Create"); len(got) != 3 {
+		t.Errorf("CallsTo(Create) = %v, want 3 calls", got)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	fake := &propagamock.Transactions{
+		GetFunc: func(ctx context.Context, id string) (*models.Transaction, error) {
+			return &models.Transaction{TransactionId: id, TransactionStatus: models.TransactionStatusPaid}, nil
+		},
+	}
+	opts := &transactions.WatchOptions{Interval: time.Millisecond}
+
+	var events []transactions.StatusChangeEvent
+	for event := range fake.Watch(context.Background(), "txn_1", opts) {
+		events = append(events, event)
+	}
+
+	if len(events) != 1 || events[0].NewStatus != models.TransactionStatusPaid {
+		t.Errorf("Watch() events = %+v, want the paid status", events)
+	}
+
+	want := []propagamock.Call{{Method: "Watch", Args: []interface{}{"txn_1", opts}}}
+	if got := fake.CallsTo("Watch"); !reflect.DeepEqual(got, want) {
+		t.Errorf("CallsTo(Watch) = %v, want %v", got, want)
+	}
+	if got := fake.CallsTo("WatchMany"); len(got) != 0 {
+		t.Errorf("Watch() recorded WatchMany calls %v", got)
+	}
+}
+
+func TestWatchManyFunc(t *testing.T) {
+	events := make(chan transactions.StatusChangeEvent)
+	close(events)
+
+	var got []string
+	fake := &propagamock.Transactions{
+		WatchManyFunc: func(ctx context.Context, ids []string, opts *transactions.WatchOptions) <-chan transactions.StatusChangeEvent {
+			got = ids
+			return events
+		},
+	}
+
+	fake.WatchMany(context.Background(), []string{"txn_1", "txn_2"}, nil)
+	if !reflect.DeepEqual(got, []string{"txn_1", "txn_2"}) || len(fake.CallsTo("WatchMany")) != 1 {
+		t.Errorf("WatchManyFunc got %v, calls %v", got, fake.Calls())
+	}
+}
@@ -0,0 +1,65 @@
+package propagamock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotImplemented is returned by the fakes when the ...Func field of a method is nil
+var ErrNotImplemented = errors.New("propagamock: method not implemented")
+
+// Call is a method call recorded by a fake
+type Call struct {
+	// Method is the name of the called method, without the Context suffix
+	Method string
+
+	// Args holds the arguments of the call, without the context
+	Args []interface{}
+}
+
+// Recorder records the calls received by a fake. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns every call recorded so far
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the calls recorded for the given method
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets every recorded call
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// record adds a call to the recorder
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// notImplemented returns ErrNotImplemented annotated with the method name
+func notImplemented(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotImplemented, method)
+}
@@ -0,0 +1,196 @@
+package propagamock
+
+import (
+	"context"
+	"iter"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/internal/batch"
+	"github.com/diogenes-moreira/propaga-sdk/internal/watch"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+	"github.com/diogenes-moreira/propaga-sdk/transactions"
+)
+
+// Transactions is a recording fake of propaga.TransactionsAPI.
+// Each method records its call and delegates to the matching ...Func field.
+type Transactions struct {
+	Recorder
+
+	ListFunc                   func(ctx context.Context, params *models.TransactionListParams) (*models.TransactionListResponse, error)
+	GetFunc                    func(ctx context.Context, id string) (*models.Transaction, error)
+	GetByExternalIDFunc        func(ctx context.Context, externalID string) (*models.Transaction, error)
+	CreateFunc                 func(ctx context.Context, params *models.TransactionCreateParams) (*models.Transaction, error)
+	UpdateFunc                 func(ctx context.Context, id string, params *models.TransactionUpdateParams) (*models.Transaction, error)
+	CancelFunc                 func(ctx context.Context, id string) (*models.Transaction, error)
+	CreateTransactionLinkFunc  func(ctx context.Context, id string, params *models.TransactionLinkParams) (*models.TransactionLinkResponse, error)
+	GetPendingTransactionsFunc func(ctx context.Context) (*models.PendingTransactionsResponse, error)
+
+	// CreateBatchFunc overrides CreateBatch, which otherwise creates each item through CreateContext
+	CreateBatchFunc func(ctx context.Context, params []*models.TransactionCreateParams, opts *transactions.BatchOptions) (*transactions.BatchResult, error)
+
+	// WatchManyFunc overrides Watch and WatchMany, which otherwise poll through GetContext
+	WatchManyFunc func(ctx context.Context, ids []string, opts *transactions.WatchOptions) <-chan transactions.StatusChangeEvent
+}
+
+var _ propaga.TransactionsAPI = (*Transactions)(nil)
+
+// List implements propaga.TransactionsAPI
+func (f *Transactions) List(params *models.TransactionListParams) (*models.TransactionListResponse, error) {
+	return f.ListContext(context.Background(), params)
+}
+
+// ListContext implements propaga.TransactionsAPI
+func (f *Transactions) ListContext(ctx context.Context, params *models.TransactionListParams) (*models.TransactionListResponse, error) {
+	f.record("List", params)
+	if f.ListFunc == nil {
+		return nil, notImplemented("Transactions.List")
+	}
+	return f.ListFunc(ctx, params)
+}
+
+// Get implements propaga.TransactionsAPI
+func (f *Transactions) Get(id string) (*models.Transaction, error) {
+	return f.GetContext(context.Background(), id)
+}
+
+// GetContext implements propaga.TransactionsAPI
+func (f *Transactions) GetContext(ctx context.Context, id string) (*models.Transaction, error) {
+	f.record("Get", id)
+	if f.GetFunc == nil {
+		return nil, notImplemented("Transactions.Get")
+	}
+	return f.GetFunc(ctx, id)
+}
+
+// GetByExternalID implements propaga.TransactionsAPI
+func (f *Transactions) GetByExternalID(externalID string) (*models.Transaction, error) {
+	return f.GetByExternalIDContext(context.Background(), externalID)
+}
+
+// GetByExternalIDContext implements propaga.TransactionsAPI
+func (f *Transactions) GetByExternalIDContext(ctx context.Context, externalID string) (*models.Transaction, error) {
+	f.record("GetByExternalID", externalID)
+	if f.GetByExternalIDFunc == nil {
+		return nil, notImplemented("Transactions.GetByExternalID")
+	}
+	return f.GetByExternalIDFunc(ctx, externalID)
+}
+
+// Create implements propaga.TransactionsAPI
+func (f *Transactions) Create(params *models.TransactionCreateParams) (*models.Transaction, error) {
+	return f.CreateContext(context.Background(), params)
+}
+
+// CreateContext implements propaga.TransactionsAPI
+func (f *Transactions) CreateContext(ctx context.Context, params *models.TransactionCreateParams) (*models.Transaction, error) {
+	f.record("Create", params)
+	if f.CreateFunc == nil {
+		return nil, notImplemented("Transactions.Create")
+	}
+	return f.CreateFunc(ctx, params)
+}
+
+// Update implements propaga.TransactionsAPI
+func (f *Transactions) Update(id string, params *models.TransactionUpdateParams) (*models.Transaction, error) {
+	return f.UpdateContext(context.Background(), id, params)
+}
+
+// UpdateContext implements propaga.TransactionsAPI
+func (f *Transactions) UpdateContext(ctx context.Context, id string, params *models.TransactionUpdateParams) (*models.Transaction, error) {
+	f.record("Update", id, params)
+	if f.UpdateFunc == nil {
+		return nil, notImplemented("Transactions.Update")
+	}
+	return f.UpdateFunc(ctx, id, params)
+}
+
+// Cancel implements propaga.TransactionsAPI
+func (f *Transactions) Cancel(id string) (*models.Transaction, error) {
+	return f.CancelContext(context.Background(), id)
+}
+
+// CancelContext implements propaga.TransactionsAPI
+func (f *Transactions) CancelContext(ctx context.Context, id string) (*models.Transaction, error) {
+	f.record("Cancel", id)
+	if f.CancelFunc == nil {
+		return nil, notImplemented("Transactions.Cancel")
+	}
+	return f.CancelFunc(ctx, id)
+}
+
+// CreateTransactionLink implements propaga.TransactionsAPI
+func (f *Transactions) CreateTransactionLink(id string, params *models.TransactionLinkParams) (*models.TransactionLinkResponse, error) {
+	return f.CreateTransactionLinkContext(context.Background(), id, params)
+}
+
+// CreateTransactionLinkContext implements propaga.TransactionsAPI
+func (f *Transactions) CreateTransactionLinkContext(ctx context.Context, id string, params *models.TransactionLinkParams) (*models.TransactionLinkResponse, error) {
+	f.record("CreateTransactionLink", id, params)
+	if f.CreateTransactionLinkFunc == nil {
+		return nil, notImplemented("Transactions.CreateTransactionLink")
+	}
+	return f.CreateTransactionLinkFunc(ctx, id, params)
+}
+
+// GetPendingTransactions implements propaga.TransactionsAPI
+func (f *Transactions) GetPendingTransactions() (*models.PendingTransactionsResponse, error) {
+	return f.GetPendingTransactionsContext(context.Background())
+}
+
+// GetPendingTransactionsContext implements propaga.TransactionsAPI
+func (f *Transactions) GetPendingTransactionsContext(ctx context.Context) (*models.PendingTransactionsResponse, error) {
+	f.record("GetPendingTransactions")
+	if f.GetPendingTransactionsFunc == nil {
+		return nil, notImplemented("Transactions.GetPendingTransactions")
+	}
+	return f.GetPendingTransactionsFunc(ctx)
+}
+
+// CreateBatch implements propaga.TransactionsAPI, on top of CreateContext unless CreateBatchFunc is set
+func (f *Transactions) CreateBatch(ctx context.Context, params []*models.TransactionCreateParams, opts *transactions.BatchOptions) (*transactions.BatchResult, error) {
+	f.record("CreateBatch", params, opts)
+	if f.CreateBatchFunc != nil {
+		return f.CreateBatchFunc(ctx, params, opts)
+	}
+	return batch.Run(ctx, f.CreateContext, params, opts)
+}
+
+// Watch implements propaga.TransactionsAPI, on top of GetContext unless WatchManyFunc is set
+func (f *Transactions) Watch(ctx context.Context, id string, opts *transactions.WatchOptions) <-chan transactions.StatusChangeEvent {
+	f.record("Watch", id, opts)
+	return f.watch(ctx, []string{id}, opts)
+}
+
+// WatchMany implements propaga.TransactionsAPI, on top of GetContext unless WatchManyFunc is set
+func (f *Transactions) WatchMany(ctx context.Context, ids []string, opts *transactions.WatchOptions) <-chan transactions.StatusChangeEvent {
+	f.record("WatchMany", ids, opts)
+	return f.watch(ctx, ids, opts)
+}
+
+// watch polls ids through WatchManyFunc, or through GetContext when it is nil
+func (f *Transactions) watch(ctx context.Context, ids []string, opts *transactions.WatchOptions) <-chan transactions.StatusChangeEvent {
+	if f.WatchManyFunc != nil {
+		return f.WatchManyFunc(ctx, ids, opts)
+	}
+	return watch.Run(ctx, f.GetContext, ids, opts)
+}
+
+// ListAll implements propaga.TransactionsAPI on top of ListContext
+func (f *Transactions) ListAll(ctx context.Context, params *models.TransactionListParams, opts *client.IteratorOptions) iter.Seq2[models.Transaction, error] {
+	return f.ListIterator(ctx, params, opts).All()
+}
+
+// ListIterator implements propaga.TransactionsAPI on top of ListContext
+func (f *Transactions) ListIterator(ctx context.Context, params *models.TransactionListParams, opts *client.IteratorOptions) *client.Iterator[models.Transaction] {
+	pageFields := func(p *models.TransactionListParams) (*int, *int) { return &p.Limit, &p.Offset }
+	list := func(ctx context.Context, page *models.TransactionListParams) ([]models.Transaction, int, error) {
+		result, err := f.ListContext(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data, result.TotalCount, nil
+	}
+
+	return client.ListIterator(ctx, params, pageFields, list, opts)
+}
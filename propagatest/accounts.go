@@ -0,0 +1,191 @@
+package propagatest
+
+import (
+	"net/http"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// accountTransitions is the account lifecycle enforced by the Server
+var accountTransitions = map[string][]string{
+	models.AccountStatusPending:   {models.AccountStatusActive, models.AccountStatusSuspended, models.AccountStatusInactive},
+	models.AccountStatusActive:    {models.AccountStatusSuspended, models.AccountStatusInactive},
+	models.AccountStatusSuspended: {models.AccountStatusActive, models.AccountStatusInactive},
+	models.AccountStatusInactive:  {models.AccountStatusActive},
+}
+
+// AddAccount stores account, assigning an ID, timestamps and the active status when missing
+func (s *Server) AddAccount(account models.Account) models.Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if account.ID == "" {
+		account.ID = s.newID("acc")
+	}
+	if account.Status == "" {
+		account.Status = models.AccountStatusActive
+	}
+	if account.CreatedAt.IsZero() {
+		account.CreatedAt = s.timestamp()
+		account.UpdatedAt = account.CreatedAt
+	}
+	s.putAccount(account)
+
+	return account
+}
+
+// Account returns the stored account with the given ID
+func (s *Server) Account(id string) (models.Account, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[id]
+	return account, ok
+}
+
+// putAccount stores account. It must be called with s.mu held.
+func (s *Server) putAccount(account models.Account) {
+	if _, ok := s.accounts[account.ID]; !ok {
+		s.accountOrder = append(s.accountOrder, account.ID)
+	}
+	s.accounts[account.ID] = account
+}
+
+func (s *Server) listAccounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	var matches []models.Account
+	for _, id := range s.accountOrder {
+		account := s.accounts[id]
+		if status := query.Get("status"); status != "" && account.Status != status {
+			continue
+		}
+		if customerID := query.Get("customer_id"); customerID != "" && account.CustomerID != customerID {
+			continue
+		}
+		if !account.CreatedAt.IsZero() && !inDateRange(r, account.CreatedAt.Time) {
+			continue
+		}
+		matches = append(matches, account)
+	}
+
+	p := parsePage(r)
+	writeJSON(w, http.StatusOK, models.AccountListResponse{
+		Data:       paginate(matches, p),
+		TotalCount: len(matches),
+		Limit:      p.limit,
+		Offset:     p.offset,
+	})
+}
+
+func (s *Server) getAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "account not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, account)
+}
+
+func (s *Server) createAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	params := &models.AccountCreateParams{}
+	if !decodeBody(w, r, params) {
+		return
+	}
+	if params.CustomerID == "" || params.Name == "" || params.PhoneNumber == "" {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "customer_id, name and phone_number are required")
+		return
+	}
+
+	now := s.timestamp()
+	account := models.Account{
+		ID:          s.newID("acc"),
+		CustomerID:  params.CustomerID,
+		Name:        params.Name,
+		Email:       params.Email,
+		PhoneNumber: params.PhoneNumber,
+		Status:      models.AccountStatusPending,
+		CreditLimit: params.CreditLimit,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Metadata:    params.Metadata,
+	}
+	s.putAccount(account)
+
+	writeJSON(w, http.StatusCreated, account)
+}
+
+func (s *Server) updateAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "account not found")
+		return
+	}
+
+	params := &models.AccountUpdateParams{}
+	if !decodeBody(w, r, params) {
+		return
+	}
+
+	if params.Status != "" && params.Status != account.Status {
+		if err := checkTransition("account", accountTransitions, account.Status, params.Status); err != nil {
+			writeError(w, http.StatusConflict, "invalid_status_transition", err.Error())
+			return
+		}
+		account.Status = params.Status
+	}
+	setIfNotEmpty(&account.Name, params.Name)
+	setIfNotEmpty(&account.Email, params.Email)
+	setIfNotEmpty(&account.PhoneNumber, params.PhoneNumber)
+	if !params.CreditLimit.IsZero() {
+		account.CreditLimit = params.CreditLimit
+	}
+	if params.Metadata != nil {
+		account.Metadata = params.Metadata
+	}
+	account.UpdatedAt = s.timestamp()
+	s.putAccount(account)
+
+	writeJSON(w, http.StatusOK, account)
+}
+
+func (s *Server) suspendAccount(w http.ResponseWriter, r *http.Request) {
+	s.changeAccountStatus(w, r, models.AccountStatusSuspended)
+}
+
+func (s *Server) activateAccount(w http.ResponseWriter, r *http.Request) {
+	s.changeAccountStatus(w, r, models.AccountStatusActive)
+}
+
+// changeAccountStatus handles the endpoints moving an account to another status
+func (s *Server) changeAccountStatus(w http.ResponseWriter, r *http.Request, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "account not found")
+		return
+	}
+	if err := checkTransition("account", accountTransitions, account.Status, status); err != nil {
+		writeError(w, http.StatusConflict, "invalid_status_transition", err.Error())
+		return
+	}
+
+	account.Status = status
+	account.UpdatedAt = s.timestamp()
+	s.putAccount(account)
+
+	writeJSON(w, http.StatusOK, account)
+}
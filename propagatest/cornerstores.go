@@ -0,0 +1,211 @@
+package propagatest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// AddCornerStore stores cs, assigning an ID, timestamps and the active status when missing
+func (s *Server) AddCornerStore(cs models.CornerStore) models.CornerStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cs.ID == "" {
+		cs.ID = s.newID("cs")
+	}
+	if cs.Status == "" {
+		cs.Status = models.CornerStoreStatusActive
+	}
+	if cs.CreatedAt.IsZero() {
+		cs.CreatedAt = s.timestamp()
+		cs.UpdatedAt = cs.CreatedAt
+	}
+	s.putCornerStore(cs)
+
+	return cs
+}
+
+// CornerStore returns the stored corner store with the given ID
+func (s *Server) CornerStore(id string) (models.CornerStore, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cs, ok := s.cornerStores[id]
+	return cs, ok
+}
+
+// SetCornerStoreInfo sets the credit information returned for a corner store external ID
+func (s *Server) SetCornerStoreInfo(externalID int, info models.CornerStoreInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cornerStoreInfo[externalID] = info
+}
+
+// putCornerStore stores cs. It must be called with s.mu held.
+func (s *Server) putCornerStore(cs models.CornerStore) {
+	if _, ok := s.cornerStores[cs.ID]; !ok {
+		s.cornerStoreOrder = append(s.cornerStoreOrder, cs.ID)
+	}
+	s.cornerStores[cs.ID] = cs
+}
+
+func (s *Server) listCornerStores(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	var matches []models.CornerStore
+	for _, id := range s.cornerStoreOrder {
+		cs := s.cornerStores[id]
+		if status := query.Get("status"); status != "" && cs.Status != status {
+			continue
+		}
+		if city := query.Get("city"); city != "" && cs.City != city {
+			continue
+		}
+		if state := query.Get("state"); state != "" && cs.State != state {
+			continue
+		}
+		if !cs.CreatedAt.IsZero() && !inDateRange(r, cs.CreatedAt.Time) {
+			continue
+		}
+		matches = append(matches, cs)
+	}
+
+	p := parsePage(r)
+	writeJSON(w, http.StatusOK, models.CornerStoreListResponse{
+		Data:       paginate(matches, p),
+		TotalCount: len(matches),
+		Limit:      p.limit,
+		Offset:     p.offset,
+	})
+}
+
+func (s *Server) getCornerStore(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cs, ok := s.cornerStores[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "corner store not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, cs)
+}
+
+func (s *Server) getCornerStoreInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	externalID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_id", "external ID must be a number")
+		return
+	}
+
+	info, ok := s.cornerStoreInfo[externalID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "corner store not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+func (s *Server) createCornerStore(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	params := &models.CornerStoreCreateParams{}
+	if !decodeBody(w, r, params) {
+		return
+	}
+	if params.Name == "" || params.Address == "" {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "name and address are required")
+		return
+	}
+
+	now := s.timestamp()
+	cs := models.CornerStore{
+		ID:          s.newID("cs"),
+		Name:        params.Name,
+		Address:     params.Address,
+		City:        params.City,
+		State:       params.State,
+		PostalCode:  params.PostalCode,
+		Country:     params.Country,
+		PhoneNumber: params.PhoneNumber,
+		Email:       params.Email,
+		Status:      models.CornerStoreStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Metadata:    params.Metadata,
+	}
+	s.putCornerStore(cs)
+
+	writeJSON(w, http.StatusCreated, cs)
+}
+
+func (s *Server) updateCornerStore(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cs, ok := s.cornerStores[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "corner store not found")
+		return
+	}
+
+	params := &models.CornerStoreUpdateParams{}
+	if !decodeBody(w, r, params) {
+		return
+	}
+
+	setIfNotEmpty(&cs.Name, params.Name)
+	setIfNotEmpty(&cs.Address, params.Address)
+	setIfNotEmpty(&cs.City, params.City)
+	setIfNotEmpty(&cs.State, params.State)
+	setIfNotEmpty(&cs.PostalCode, params.PostalCode)
+	setIfNotEmpty(&cs.Country, params.Country)
+	setIfNotEmpty(&cs.PhoneNumber, params.PhoneNumber)
+	setIfNotEmpty(&cs.Email, params.Email)
+	setIfNotEmpty(&cs.Status, params.Status)
+	if params.Metadata != nil {
+		cs.Metadata = params.Metadata
+	}
+	cs.UpdatedAt = s.timestamp()
+	s.putCornerStore(cs)
+
+	writeJSON(w, http.StatusOK, cs)
+}
+
+func (s *Server) deleteCornerStore(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.cornerStores[id]; !ok {
+		writeError(w, http.StatusNotFound, "not_found", "corner store not found")
+		return
+	}
+
+	delete(s.cornerStores, id)
+	for i, storedID := range s.cornerStoreOrder {
+		if storedID == id {
+			s.cornerStoreOrder = append(s.cornerStoreOrder[:i], s.cornerStoreOrder[i+1:]...)
+			break
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setIfNotEmpty replaces *dst with value unless value is empty
+func setIfNotEmpty[T comparable](dst *T, value T) {
+	var zero T
+	if value != zero {
+		*dst = value
+	}
+}
@@ -0,0 +1,212 @@
+package propagatest
+
+import (
+	"net/http"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// kycTransitions is the KYC lifecycle enforced by the Server
+var kycTransitions = map[string][]string{
+	models.KYCStatusPending:  {models.KYCStatusVerified, models.KYCStatusRejected, models.KYCStatusExpired},
+	models.KYCStatusVerified: {models.KYCStatusExpired},
+}
+
+// AddKYC stores kyc, assigning an ID, timestamps and the pending status when missing
+func (s *Server) AddKYC(kyc models.KYC) models.KYC {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if kyc.ID == "" {
+		kyc.ID = s.newID("kyc")
+	}
+	if kyc.Status == "" {
+		kyc.Status = models.KYCStatusPending
+	}
+	if kyc.CreatedAt.IsZero() {
+		kyc.CreatedAt = s.timestamp()
+		kyc.UpdatedAt = kyc.CreatedAt
+	}
+	s.putKYC(kyc)
+
+	return kyc
+}
+
+// KYC returns the stored KYC verification with the given ID
+func (s *Server) KYC(id string) (models.KYC, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kyc, ok := s.kycs[id]
+	return kyc, ok
+}
+
+// putKYC stores kyc. It must be called with s.mu held.
+func (s *Server) putKYC(kyc models.KYC) {
+	if _, ok := s.kycs[kyc.ID]; !ok {
+		s.kycOrder = append(s.kycOrder, kyc.ID)
+	}
+	s.kycs[kyc.ID] = kyc
+}
+
+// transitionKYC moves kyc to status, setting the timestamps it implies.
+// It must be called with s.mu held.
+func (s *Server) transitionKYC(kyc *models.KYC, status, reason string) error {
+	if err := checkTransition("KYC verification", kycTransitions, kyc.Status, status); err != nil {
+		return err
+	}
+
+	now := s.timestamp()
+	kyc.Status = status
+	kyc.UpdatedAt = now
+	switch status {
+	case models.KYCStatusVerified:
+		kyc.VerifiedAt = now
+	case models.KYCStatusRejected:
+		kyc.RejectedAt = now
+		kyc.RejectReason = reason
+	}
+
+	return nil
+}
+
+func (s *Server) listKYCs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	var matches []models.KYC
+	for _, id := range s.kycOrder {
+		kyc := s.kycs[id]
+		if status := query.Get("status"); status != "" && kyc.Status != status {
+			continue
+		}
+		if customerID := query.Get("customer_id"); customerID != "" && kyc.CustomerID != customerID {
+			continue
+		}
+		if !kyc.CreatedAt.IsZero() && !inDateRange(r, kyc.CreatedAt.Time) {
+			continue
+		}
+		matches = append(matches, kyc)
+	}
+
+	p := parsePage(r)
+	writeJSON(w, http.StatusOK, models.KYCListResponse{
+		Data:       paginate(matches, p),
+		TotalCount: len(matches),
+		Limit:      p.limit,
+		Offset:     p.offset,
+	})
+}
+
+func (s *Server) getKYC(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kyc, ok := s.kycs[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "KYC verification not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, kyc)
+}
+
+func (s *Server) createKYC(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	params := &models.KYCCreateParams{}
+	if !decodeBody(w, r, params) {
+		return
+	}
+	if params.CustomerID == "" || params.DocumentType == "" || params.DocumentID == "" || params.FullName == "" {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "customer_id, document_type, document_id and full_name are required")
+		return
+	}
+
+	now := s.timestamp()
+	kyc := models.KYC{
+		ID:           s.newID("kyc"),
+		CustomerID:   params.CustomerID,
+		Status:       models.KYCStatusPending,
+		DocumentType: params.DocumentType,
+		DocumentID:   params.DocumentID,
+		FullName:     params.FullName,
+		DateOfBirth:  params.DateOfBirth,
+		Address:      params.Address,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Metadata:     params.Metadata,
+	}
+	s.putKYC(kyc)
+
+	writeJSON(w, http.StatusCreated, kyc)
+}
+
+func (s *Server) updateKYC(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kyc, ok := s.kycs[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "KYC verification not found")
+		return
+	}
+
+	params := &models.KYCUpdateParams{}
+	if !decodeBody(w, r, params) {
+		return
+	}
+
+	if params.Status != "" && params.Status != kyc.Status {
+		if err := s.transitionKYC(&kyc, params.Status, params.RejectReason); err != nil {
+			writeError(w, http.StatusConflict, "invalid_status_transition", err.Error())
+			return
+		}
+	}
+	setIfNotEmpty(&kyc.DocumentType, params.DocumentType)
+	setIfNotEmpty(&kyc.DocumentID, params.DocumentID)
+	setIfNotEmpty(&kyc.FullName, params.FullName)
+	setIfNotEmpty(&kyc.DateOfBirth, params.DateOfBirth)
+	setIfNotEmpty(&kyc.Address, params.Address)
+	if params.Metadata != nil {
+		kyc.Metadata = params.Metadata
+	}
+	kyc.UpdatedAt = s.timestamp()
+	s.putKYC(kyc)
+
+	writeJSON(w, http.StatusOK, kyc)
+}
+
+func (s *Server) verifyKYC(w http.ResponseWriter, r *http.Request) {
+	s.changeKYCStatus(w, r, models.KYCStatusVerified, "")
+}
+
+func (s *Server) rejectKYC(w http.ResponseWriter, r *http.Request) {
+	payload := struct {
+		Reason string `json:"reason"`
+	}{}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	s.changeKYCStatus(w, r, models.KYCStatusRejected, payload.Reason)
+}
+
+// changeKYCStatus handles the endpoints moving a KYC verification to another status
+func (s *Server) changeKYCStatus(w http.ResponseWriter, r *http.Request, status, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kyc, ok := s.kycs[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "KYC verification not found")
+		return
+	}
+	if err := s.transitionKYC(&kyc, status, reason); err != nil {
+		writeError(w, http.StatusConflict, "invalid_status_transition", err.Error())
+		return
+	}
+	s.putKYC(kyc)
+
+	writeJSON(w, http.StatusOK, kyc)
+}
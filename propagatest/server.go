@@ -0,0 +1,406 @@
+package propagatest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// DefaultAPIKey is the API key accepted by a Server unless another one is configured
+const DefaultAPIKey = "propagatest-api-key"
+
+// Server is an in-memory fake of the Propaga API backed by an httptest.Server.
+// It implements every endpoint called by the SDK services and keeps its state in memory.
+// It enforces the status lifecycles of KYC verifications, accounts and transactions, the
+// latter as defined by models.TransactionStatus, and lets tests inject failures and latency.
+//
+//	srv := propagatest.NewServer()
+//	defer srv.Close()
+//	client := srv.Client()
+type Server struct {
+	// URL is the base URL of the fake API
+	URL string
+
+	// APIKey is the API key expected in the Authorization header
+	APIKey string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	now      func() time.Time
+	latency  time.Duration
+	failures []*Failure
+	requests []Request
+	nextID   int
+
+	transactions     map[string]models.Transaction
+	transactionOrder []string
+	idempotency      map[string]idempotentResult
+	cornerStores     map[string]models.CornerStore
+	cornerStoreOrder []string
+	cornerStoreInfo  map[int]models.CornerStoreInfo
+	kycs             map[string]models.KYC
+	kycOrder         []string
+	accounts         map[string]models.Account
+	accountOrder     []string
+}
+
+// Request is a request received by the Server
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// Failure describes an error injected in the responses of the Server
+type Failure struct {
+	// Method restricts the failure to an HTTP method, any method when empty
+	Method string
+
+	// Path restricts the failure to a request path, any path when empty.
+	// A trailing "*" matches every path with the given prefix.
+	Path string
+
+	// Status is the HTTP status code of the injected response
+	Status int
+
+	// Code and Message are sent in the APIError body of the injected response
+	Code    string
+	Message string
+
+	// Header holds headers added to the injected response, such as Retry-After
+	Header http.Header
+
+	// Times is the number of requests that fail, zero means every matching request
+	Times int
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithAPIKey sets the API key expected by the Server
+func WithAPIKey(apiKey string) Option {
+	return func(s *Server) {
+		s.APIKey = apiKey
+	}
+}
+
+// WithClock replaces the clock used to timestamp the resources
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer starts a new fake Propaga API. Callers must Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		APIKey:          DefaultAPIKey,
+		now:             time.Now,
+		transactions:    make(map[string]models.Transaction),
+		idempotency:     make(map[string]idempotentResult),
+		cornerStores:    make(map[string]models.CornerStore),
+		cornerStoreInfo: make(map[int]models.CornerStoreInfo),
+		kycs:            make(map[string]models.KYC),
+		accounts:        make(map[string]models.Account),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.server = httptest.NewServer(s.routes())
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the Server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a Propaga client targeting the Server
+func (s *Server) Client(opts ...propaga.Option) *propaga.Client {
+	opts = append([]propaga.Option{propaga.WithBaseURL(s.URL), propaga.WithHTTPClient(s.server.Client())}, opts...)
+	return propaga.New(s.APIKey, opts...)
+}
+
+// SetLatency delays every response of the Server by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Fail injects a failure in the responses of the Server
+func (s *Server) Fail(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &f)
+}
+
+// FailNext makes the next request matching method and path fail with status
+func (s *Server) FailNext(method, path string, status int) {
+	s.Fail(Failure{Method: method, Path: path, Status: status, Times: 1})
+}
+
+// ClearFailures removes every injected failure
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = nil
+}
+
+// Requests returns the requests received by the Server so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// routes registers the handlers of every endpoint
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v1/transaction", s.listTransactions)
+	mux.HandleFunc("POST /v1/transaction", s.createTransaction)
+	mux.HandleFunc("GET /v1/transaction/pending", s.pendingTransactions)
+	mux.HandleFunc("GET /v1/transaction/external/{id}", s.getTransactionByExternalID)
+	mux.HandleFunc("GET /v1/transaction/{id}", s.getTransaction)
+	mux.HandleFunc("PUT /v1/transaction/{id}", s.updateTransaction)
+	mux.HandleFunc("POST /v1/transaction/{id}/cancel", s.cancelTransaction)
+	mux.HandleFunc("POST /v1/link/external/{id}", s.createTransactionLink)
+
+	mux.HandleFunc("GET /v1/corner-store", s.listCornerStores)
+	mux.HandleFunc("POST /v1/corner-store", s.createCornerStore)
+	mux.HandleFunc("GET /v1/corner-store/external/{id}", s.getCornerStoreInfo)
+	mux.HandleFunc("GET /v1/corner-store/{id}", s.getCornerStore)
+	mux.HandleFunc("PUT /v1/corner-store/{id}", s.updateCornerStore)
+	mux.HandleFunc("DELETE /v1/corner-store/{id}", s.deleteCornerStore)
+
+	mux.HandleFunc("GET /v1/kyc", s.listKYCs)
+	mux.HandleFunc("POST /v1/kyc", s.createKYC)
+	mux.HandleFunc("GET /v1/kyc/{id}", s.getKYC)
+	mux.HandleFunc("PUT /v1/kyc/{id}", s.updateKYC)
+	mux.HandleFunc("POST /v1/kyc/{id}/verify", s.verifyKYC)
+	mux.HandleFunc("POST /v1/kyc/{id}/reject", s.rejectKYC)
+
+	mux.HandleFunc("GET /v1/accounts", s.listAccounts)
+	mux.HandleFunc("POST /v1/accounts", s.createAccount)
+	mux.HandleFunc("GET /v1/accounts/{id}", s.getAccount)
+	mux.HandleFunc("PUT /v1/accounts/{id}", s.updateAccount)
+	mux.HandleFunc("POST /v1/accounts/{id}/suspend", s.suspendAccount)
+	mux.HandleFunc("POST /v1/accounts/{id}/activate", s.activateAccount)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.RawQuery,
+			Header: r.Header.Clone(),
+			Body:   body,
+		})
+		latency := s.latency
+		failure := s.matchFailure(r)
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if failure != nil {
+			for key, values := range failure.Header {
+				w.Header()[key] = values
+			}
+			code, message := failure.Code, failure.Message
+			if code == "" {
+				code = strings.ReplaceAll(strings.ToLower(http.StatusText(failure.Status)), " ", "_")
+			}
+			if message == "" {
+				message = "injected failure"
+			}
+			writeError(w, failure.Status, code, message)
+			return
+		}
+
+		if r.Header.Get("Authorization") != s.APIKey {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "invalid API key")
+			return
+		}
+
+		mux.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), bodyKey{}, body)))
+	})
+}
+
+// matchFailure returns the injected failure matching r, consuming one of its occurrences.
+// It must be called with s.mu held.
+func (s *Server) matchFailure(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(f.Path, "*"); ok {
+			if !strings.HasPrefix(r.URL.Path, prefix) {
+				continue
+			}
+		} else if f.Path != "" && f.Path != r.URL.Path {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+			}
+		}
+		return f
+	}
+
+	return nil
+}
+
+// checkTransition returns an error when a resource cannot move from one status to another.
+// It serves the KYC and account lifecycles, which have no typed state machine in models;
+// transactions are checked with models.TransactionStatus.ValidateTransition.
+func checkTransition(resource string, transitions map[string][]string, from, to string) error {
+	if !slices.Contains(transitions[from], to) {
+		return fmt.Errorf("%s cannot move from %q to %q", resource, from, to)
+	}
+	return nil
+}
+
+// newID generates a resource identifier. It must be called with s.mu held.
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s_%d", prefix, s.nextID)
+}
+
+// timestamp returns the current time formatted as the API does
+func (s *Server) timestamp() models.Timestamp {
+	return models.TimestampOf(s.now().UTC().Truncate(time.Second))
+}
+
+// page holds the pagination parameters of a List request
+type page struct {
+	limit  int
+	offset int
+}
+
+// parsePage reads the limit and offset query parameters
+func parsePage(r *http.Request) page {
+	p := page{limit: 10}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit > 0 {
+		p.limit = limit
+	}
+	if offset, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && offset > 0 {
+		p.offset = offset
+	}
+	return p
+}
+
+// paginate returns the items of the requested page
+func paginate[T any](items []T, p page) []T {
+	if p.offset >= len(items) {
+		return []T{}
+	}
+	end := min(p.offset+p.limit, len(items))
+	return items[p.offset:end]
+}
+
+// inDateRange reports whether value falls between the start_date and end_date query parameters
+func inDateRange(r *http.Request, value time.Time) bool {
+	if start, ok := parseDate(r.URL.Query().Get("start_date")); ok && value.Before(start) {
+		return false
+	}
+	if end, ok := parseDate(r.URL.Query().Get("end_date")); ok {
+		if len(r.URL.Query().Get("end_date")) == len(time.DateOnly) {
+			end = end.AddDate(0, 0, 1)
+		}
+		if !value.Before(end) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDate parses a date in RFC 3339 or YYYY-MM-DD format
+func parseDate(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// readBody reads the request body and restores it for the handlers
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// bodyKey is the context key of the raw request body
+type bodyKey struct{}
+
+// requestBody returns the raw body of a request received by the Server
+func requestBody(r *http.Request) []byte {
+	body, _ := r.Context().Value(bodyKey{}).([]byte)
+	return body
+}
+
+// decodeBody decodes the JSON body of r into v, answering with status 400 on failure
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", fmt.Sprintf("invalid JSON body: %v", err))
+		return false
+	}
+	return true
+}
+
+// writeJSON encodes v as the JSON body of the response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError answers with an APIError body
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, models.APIError{Code: code, Message: message})
+}
@@ -0,0 +1,201 @@
+package propagatest_test
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+	"github.com/diogenes-moreira/propaga-sdk/propagatest"
+)
+
+// createParams returns the parameters of a valid transaction
+func createParams(key string) *models.TransactionCreateParams {
+	return &models.TransactionCreateParams{
+		CornerStoreId:           "cs-1",
+		TotalAmount:             models.MXN(10000),
+		WholesalerTransactionId: "order-1",
+		DeliveryDate:            models.NewDate(2025, time.January, 2),
+		Products:                []models.Product{{ExternalSKU: "sku-1", Name: "Product", Quantity: 1}},
+		IdempotencyKey:          key,
+	}
+}
+
+func TestIdempotentCreate(t *testing.T) {
+	server := propagatest.NewServer()
+	defer server.Close()
+	service := server.Client().Transactions
+
+	first, err := service.Create(createParams("key-1"))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	replayed, err := service.Create(createParams("key-1"))
+	if err != nil {
+		t.Fatalf("Create() replay error = %v", err)
+	}
+	if replayed.TransactionId != first.TransactionId {
+		t.Errorf("replay created %s, want %s", replayed.TransactionId, first.TransactionId)
+	}
+
+	other, err := service.Create(createParams("key-2"))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if other.TransactionId == first.TransactionId {
+		t.Errorf("a new key replayed %s", first.TransactionId)
+	}
+
+	changed := createParams("key-1")
+	changed.TotalAmount = models.MXN(20000)
+	if _, err := service.Create(changed); !client.IsIdempotencyConflict(err) {
+		t.Errorf("Create() with a reused key and another body error = %v, want an idempotency conflict", err)
+	}
+
+	list, err := service.List(nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if list.TotalCount != 2 {
+		t.Errorf("got %d transactions, want 2", list.TotalCount)
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	server := propagatest.NewServer()
+	defer server.Close()
+	for range 5 {
+		server.AddTransaction(models.Transaction{})
+	}
+	server.AddTransaction(models.Transaction{TransactionStatus: models.TransactionStatusPaid})
+
+	tests := []struct {
+		name      string
+		params    *models.TransactionListParams
+		wantIDs   []string
+		wantTotal int
+	}{
+		{name: "default", params: nil, wantIDs: []string{"txn_1", "txn_2", "txn_3", "txn_4", "txn_5", "txn_6"}, wantTotal: 6},
+		{name: "limit", params: &models.TransactionListParams{Limit: 2}, wantIDs: []string{"txn_1", "txn_2"}, wantTotal: 6},
+		{name: "offset", params: &models.TransactionListParams{Limit: 2, Offset: 4}, wantIDs: []string{"txn_5", "txn_6"}, wantTotal: 6},
+		{name: "past the end", params: &models.TransactionListParams{Offset: 10}, wantIDs: []string{}, wantTotal: 6},
+		{name: "filtered", params: &models.TransactionListParams{Status: models.TransactionStatusPending, Offset: 3}, wantIDs: []string{"txn_4", "txn_5"}, wantTotal: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := server.Client().Transactions.List(tt.params)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			ids := []string{}
+			for _, tx := range list.Data {
+				ids = append(ids, tx.TransactionId)
+			}
+			if !slices.Equal(ids, tt.wantIDs) || list.TotalCount != tt.wantTotal {
+				t.Errorf("List() = %v (total %d), want %v (total %d)", ids, list.TotalCount, tt.wantIDs, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestTransactionTransitions(t *testing.T) {
+	server := propagatest.NewServer()
+	defer server.Close()
+	tx := server.AddTransaction(models.Transaction{})
+
+	if err := server.SetTransactionStatus(tx.TransactionId, models.TransactionStatusExpired); err != nil {
+		t.Fatalf("SetTransactionStatus(expired) error = %v", err)
+	}
+	if err := server.SetTransactionStatus(tx.TransactionId, models.TransactionStatusPaid); !errors.Is(err, models.ErrInvalidTransition) {
+		t.Errorf("SetTransactionStatus(paid) from expired error = %v, want ErrInvalidTransition", err)
+	}
+	if err := server.SetTransactionStatus("txn_missing", models.TransactionStatusPaid); err == nil {
+		t.Errorf("SetTransactionStatus() of a missing transaction succeeded")
+	}
+
+	paid := server.AddTransaction(models.Transaction{TransactionStatus: models.TransactionStatusPaid})
+	_, err := server.Client().Transactions.Update(paid.TransactionId, &models.TransactionUpdateParams{Status: models.TransactionStatusPending})
+	if !client.IsConflict(err) {
+		t.Fatalf("Update() error = %v, want a conflict", err)
+	}
+	if _, err := server.Client().Transactions.Cancel(paid.TransactionId); !client.IsConflict(err) {
+		t.Errorf("Cancel() of a paid transaction error = %v, want a conflict", err)
+	}
+
+	delivered := server.AddTransaction(models.Transaction{TransactionStatus: models.TransactionStatusOnHold})
+	if err := server.SetTransactionStatus(delivered.TransactionId, models.TransactionStatusDelivery); err != nil {
+		t.Fatalf("SetTransactionStatus(delivery) error = %v", err)
+	}
+	got, _ := server.Transaction(delivered.TransactionId)
+	if got.TransactionStatus != models.TransactionStatusDelivery || got.DeliveryDate.IsZero() {
+		t.Errorf("delivered transaction = %+v, want the delivery status and date", got)
+	}
+}
+
+func TestKYCAndAccountTransitions(t *testing.T) {
+	server := propagatest.NewServer()
+	defer server.Close()
+	api := server.Client()
+
+	kyc := server.AddKYC(models.KYC{})
+	if _, err := api.KYC.Reject(kyc.ID, "blurry document"); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if _, err := api.KYC.Verify(kyc.ID); !client.IsConflict(err) {
+		t.Errorf("Verify() of a rejected KYC error = %v, want a conflict", err)
+	}
+
+	account := server.AddAccount(models.Account{})
+	if _, err := api.Accounts.Suspend(account.ID); err != nil {
+		t.Fatalf("Suspend() error = %v", err)
+	}
+	if _, err := api.Accounts.Suspend(account.ID); !client.IsConflict(err) {
+		t.Errorf("Suspend() of a suspended account error = %v, want a conflict", err)
+	}
+	if _, err := api.Accounts.Activate(account.ID); err != nil {
+		t.Errorf("Activate() error = %v", err)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	server := propagatest.NewServer(propagatest.WithAPIKey("secret"))
+	defer server.Close()
+	server.AddTransaction(models.Transaction{})
+
+	if _, err := server.Client().Transactions.List(nil); err != nil {
+		t.Fatalf("List() with the server key error = %v", err)
+	}
+
+	for _, key := range []string{"wrong", ""} {
+		api := propaga.New(key, propaga.WithBaseURL(server.URL))
+		if _, err := api.Transactions.List(nil); !client.IsUnauthorized(err) {
+			t.Errorf("List() with key %q error = %v, want unauthorized", key, err)
+		}
+	}
+
+	requests := server.Requests()
+	if len(requests) != 3 || requests[0].Header.Get("Authorization") != "secret" {
+		t.Errorf("got requests %+v, want 3 starting with the server key", requests)
+	}
+}
+
+func TestFailureInjection(t *testing.T) {
+	server := propagatest.NewServer()
+	defer server.Close()
+	tx := server.AddTransaction(models.Transaction{})
+	service := server.Client(propaga.WithRetryPolicy(nil)).Transactions
+
+	server.FailNext(http.MethodGet, "/v1/transaction/*", http.StatusNotFound)
+	if _, err := service.Get(tx.TransactionId); !client.IsNotFound(err) {
+		t.Fatalf("Get() error = %v, want the injected not found", err)
+	}
+	if _, err := service.Get(tx.TransactionId); err != nil {
+		t.Errorf("Get() after the injected failure error = %v", err)
+	}
+}
@@ -0,0 +1,346 @@
+package propagatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// idempotentResult is the response stored for an idempotency key
+type idempotentResult struct {
+	body     []byte
+	status   int
+	response []byte
+}
+
+// pendingTransaction is an entry of the pending transactions response
+type pendingTransaction struct {
+	Id                       string           `json:"id"`
+	CornerStoreId            string           `json:"cornerStoreId"`
+	WholesalerTransactionId  string           `json:"wholesalerTransactionId"`
+	TotalAmount              models.Money     `json:"totalAmount"`
+	Interests                models.Money     `json:"interests"`
+	IVAAmount                models.Money     `json:"IVAAmount"`
+	TotalAmountWithInterests models.Money     `json:"totalAmountWithInterests"`
+	MovementDate             models.Timestamp `json:"movementDate"`
+	DeliveryDate             models.Timestamp `json:"deliveryDate"`
+}
+
+// AddTransaction stores tx, assigning an ID and the pending status when missing
+func (s *Server) AddTransaction(tx models.Transaction) models.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tx.TransactionId == "" {
+		tx.TransactionId = s.newID("txn")
+	}
+	if tx.TransactionStatus == "" {
+		tx.TransactionStatus = models.TransactionStatusPending
+	}
+	s.putTransaction(tx)
+
+	return tx
+}
+
+// Transaction returns the stored transaction with the given ID
+func (s *Server) Transaction(id string) (models.Transaction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.transactions[id]
+	return tx, ok
+}
+
+// SetTransactionStatus moves a transaction to status, as Propaga does when the order
+// progresses. It fails when the transition is not allowed by the lifecycle.
+func (s *Server) SetTransactionStatus(id string, status models.TransactionStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.transactions[id]
+	if !ok {
+		return fmt.Errorf("transaction %s not found", id)
+	}
+	if err := tx.TransactionStatus.ValidateTransition(status); err != nil {
+		return err
+	}
+
+	s.applyTransactionStatus(&tx, status)
+	s.putTransaction(tx)
+
+	return nil
+}
+
+// putTransaction stores tx. It must be called with s.mu held.
+func (s *Server) putTransaction(tx models.Transaction) {
+	if _, ok := s.transactions[tx.TransactionId]; !ok {
+		s.transactionOrder = append(s.transactionOrder, tx.TransactionId)
+	}
+	s.transactions[tx.TransactionId] = tx
+}
+
+// applyTransactionStatus sets the status of tx along with the dates it implies
+func (s *Server) applyTransactionStatus(tx *models.Transaction, status models.TransactionStatus) {
+	tx.TransactionStatus = status
+	switch status {
+	case models.TransactionStatusDelivery:
+		tx.DeliveryDate = s.timestamp()
+	case models.TransactionStatusPaid:
+		tx.PaymentDate = s.timestamp()
+	}
+}
+
+func (s *Server) listTransactions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	var matches []models.Transaction
+	for _, id := range s.transactionOrder {
+		tx := s.transactions[id]
+		if status := query.Get("status"); status != "" && string(tx.TransactionStatus) != status {
+			continue
+		}
+		if customerID := query.Get("customer_id"); customerID != "" && tx.UserId != customerID {
+			continue
+		}
+		if !inDateRange(r, tx.MovementDate.Time) {
+			continue
+		}
+		matches = append(matches, tx)
+	}
+
+	p := parsePage(r)
+	writeJSON(w, http.StatusOK, models.TransactionListResponse{
+		Data:       paginate(matches, p),
+		TotalCount: len(matches),
+		Limit:      p.limit,
+		Offset:     p.offset,
+	})
+}
+
+func (s *Server) getTransaction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.transactions[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "transaction not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, tx)
+}
+
+func (s *Server) getTransactionByExternalID(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range s.transactionOrder {
+		if tx := s.transactions[id]; tx.WholesalerTransactionId == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, tx)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "transaction not found")
+}
+
+func (s *Server) createTransaction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.replayIdempotent(w, r) {
+		return
+	}
+
+	params := &models.TransactionCreateParams{}
+	if !decodeBody(w, r, params) {
+		return
+	}
+	if params.CornerStoreId == "" || !params.TotalAmount.IsPositive() || len(params.Products) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "cornerStoreId, a positive totalAmount and products are required")
+		return
+	}
+
+	tx := s.newTransaction(params.CornerStoreId, params.WholesalerTransactionId, params.TotalAmount, params.Products, params.Metadata)
+	if !params.DeliveryDate.IsZero() {
+		tx.DeliveryDate = models.TimestampOf(params.DeliveryDate.Time)
+	}
+	s.putTransaction(tx)
+
+	s.respondIdempotent(w, r, http.StatusCreated, tx)
+}
+
+func (s *Server) updateTransaction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.transactions[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "transaction not found")
+		return
+	}
+
+	params := &models.TransactionUpdateParams{}
+	if !decodeBody(w, r, params) {
+		return
+	}
+
+	if params.Status != "" && params.Status != tx.TransactionStatus {
+		if err := tx.TransactionStatus.ValidateTransition(params.Status); err != nil {
+			writeError(w, http.StatusConflict, "invalid_status_transition", err.Error())
+			return
+		}
+		s.applyTransactionStatus(&tx, params.Status)
+	}
+	if !params.TransactionAmount.IsZero() {
+		tx.TotalAmount = params.TransactionAmount
+		tx.TotalAmountWithInterests = params.TransactionAmount.Add(tx.Interests).Add(tx.IVAAmount)
+	}
+	if params.Metadata != nil {
+		tx.Metadata = params.Metadata
+	}
+	s.putTransaction(tx)
+
+	writeJSON(w, http.StatusOK, tx)
+}
+
+func (s *Server) cancelTransaction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.transactions[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "transaction not found")
+		return
+	}
+	if err := tx.TransactionStatus.ValidateTransition(models.TransactionStatusCancelled); err != nil {
+		writeError(w, http.StatusConflict, "invalid_status_transition", err.Error())
+		return
+	}
+
+	s.applyTransactionStatus(&tx, models.TransactionStatusCancelled)
+	s.putTransaction(tx)
+
+	writeJSON(w, http.StatusOK, tx)
+}
+
+func (s *Server) createTransactionLink(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.replayIdempotent(w, r) {
+		return
+	}
+
+	params := &models.TransactionLinkParams{}
+	if !decodeBody(w, r, params) {
+		return
+	}
+	if params.Transaction.CornerStoreId == "" || !params.Transaction.TotalAmount.IsPositive() {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "cornerStoreId and a positive totalAmount are required")
+		return
+	}
+
+	wholesalerTransactionID := params.Transaction.WholesalerTransactionId
+	if wholesalerTransactionID == "" {
+		wholesalerTransactionID = r.PathValue("id")
+	}
+	tx := s.newTransaction(params.Transaction.CornerStoreId, wholesalerTransactionID, params.Transaction.TotalAmount, params.Transaction.Products, params.Transaction.Metadata)
+	s.putTransaction(tx)
+
+	s.respondIdempotent(w, r, http.StatusCreated, models.TransactionLinkResponse{
+		Link:          fmt.Sprintf("%s/checkout/%s", s.URL, tx.TransactionId),
+		TransactionId: tx.TransactionId,
+	})
+}
+
+func (s *Server) pendingTransactions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pending []pendingTransaction
+	for _, id := range s.transactionOrder {
+		tx := s.transactions[id]
+		if tx.TransactionStatus != models.TransactionStatusPending {
+			continue
+		}
+		pending = append(pending, pendingTransaction{
+			Id:                       tx.TransactionId,
+			CornerStoreId:            tx.CornerStoreId,
+			WholesalerTransactionId:  tx.WholesalerTransactionId,
+			TotalAmount:              tx.TotalAmount,
+			Interests:                tx.Interests,
+			IVAAmount:                tx.IVAAmount,
+			TotalAmountWithInterests: tx.TotalAmountWithInterests,
+			MovementDate:             tx.MovementDate,
+			DeliveryDate:             tx.DeliveryDate,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"transactions": pending})
+}
+
+// newTransaction builds a pending transaction. It must be called with s.mu held.
+func (s *Server) newTransaction(cornerStoreID, wholesalerTransactionID string, total models.Money, products []models.Product, metadata models.Metadata) models.Transaction {
+	tx := models.Transaction{
+		TransactionId:            s.newID("txn"),
+		CornerStoreId:            cornerStoreID,
+		TransactionStatus:        models.TransactionStatusPending,
+		WholesalerTransactionId:  wholesalerTransactionID,
+		MovementDate:             s.timestamp(),
+		TotalAmount:              total,
+		TotalAmountWithInterests: total,
+		Products:                 products,
+		Metadata:                 metadata,
+	}
+	for _, info := range s.cornerStoreInfo {
+		if info.CornerStoreId == cornerStoreID {
+			tx.UserId = info.UserId
+		}
+	}
+
+	return tx
+}
+
+// replayIdempotent answers with the stored response when the request reuses an idempotency key.
+// A key reused with a different body is answered with status 409. It must be called with s.mu held.
+func (s *Server) replayIdempotent(w http.ResponseWriter, r *http.Request) bool {
+	key := r.Header.Get(client.IdempotencyKeyHeader)
+	if key == "" {
+		return false
+	}
+
+	stored, ok := s.idempotency[key]
+	if !ok {
+		return false
+	}
+
+	if !bytes.Equal(requestBody(r), stored.body) {
+		writeError(w, http.StatusConflict, "idempotency_conflict", "idempotency key reused with a different request")
+		return true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.status)
+	_, _ = w.Write(stored.response)
+
+	return true
+}
+
+// respondIdempotent writes v and stores it for the idempotency key of the request, if any.
+// It must be called with s.mu held.
+func (s *Server) respondIdempotent(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	response, _ := json.Marshal(v)
+	if key := r.Header.Get(client.IdempotencyKeyHeader); key != "" {
+		s.idempotency[key] = idempotentResult{body: requestBody(r), status: status, response: response}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(response)
+}
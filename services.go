@@ -0,0 +1,192 @@
+package propaga
+
+import (
+	"context"
+	"iter"
+
+	"github.com/diogenes-moreira/propaga-sdk/account"
+	"github.com/diogenes-moreira/propaga-sdk/auth"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/cornerstore"
+	"github.com/diogenes-moreira/propaga-sdk/kyc"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+	"github.com/diogenes-moreira/propaga-sdk/transactions"
+)
+
+// AuthAPI is the interface of the authentication service, implemented by *auth.Service
+type AuthAPI interface {
+	ValidateToken() (bool, error)
+	ValidateTokenContext(ctx context.Context) (bool, error)
+}
+
+// TransactionReader reads single transactions
+type TransactionReader interface {
+	Get(id string) (*models.Transaction, error)
+	GetContext(ctx context.Context, id string) (*models.Transaction, error)
+	GetByExternalID(externalID string) (*models.Transaction, error)
+	GetByExternalIDContext(ctx context.Context, externalID string) (*models.Transaction, error)
+}
+
+// TransactionLister lists transactions, one page at a time or through an iterator
+type TransactionLister interface {
+	List(params *models.TransactionListParams) (*models.TransactionListResponse, error)
+	ListContext(ctx context.Context, params *models.TransactionListParams) (*models.TransactionListResponse, error)
+	ListAll(ctx context.Context, params *models.TransactionListParams, opts *client.IteratorOptions) iter.Seq2[models.Transaction, error]
+	ListIterator(ctx context.Context, params *models.TransactionListParams, opts *client.IteratorOptions) *client.Iterator[models.Transaction]
+	GetPendingTransactions() (*models.PendingTransactionsResponse, error)
+	GetPendingTransactionsContext(ctx context.Context) (*models.PendingTransactionsResponse, error)
+}
+
+// TransactionCreator creates transactions and payment links
+type TransactionCreator interface {
+	Create(params *models.TransactionCreateParams) (*models.Transaction, error)
+	CreateContext(ctx context.Context, params *models.TransactionCreateParams) (*models.Transaction, error)
+	CreateTransactionLink(id string, params *models.TransactionLinkParams) (*models.TransactionLinkResponse, error)
+	CreateTransactionLinkContext(ctx context.Context, id string, params *models.TransactionLinkParams) (*models.TransactionLinkResponse, error)
+	CreateBatch(ctx context.Context, params []*models.TransactionCreateParams, opts *transactions.BatchOptions) (*transactions.BatchResult, error)
+}
+
+// TransactionUpdater updates and cancels transactions
+type TransactionUpdater interface {
+	Update(id string, params *models.TransactionUpdateParams) (*models.Transaction, error)
+	UpdateContext(ctx context.Context, id string, params *models.TransactionUpdateParams) (*models.Transaction, error)
+	Cancel(id string) (*models.Transaction, error)
+	CancelContext(ctx context.Context, id string) (*models.Transaction, error)
+}
+
+// TransactionWatcher polls transactions for status changes
+type TransactionWatcher interface {
+	Watch(ctx context.Context, id string, opts *transactions.WatchOptions) <-chan transactions.StatusChangeEvent
+	WatchMany(ctx context.Context, ids []string, opts *transactions.WatchOptions) <-chan transactions.StatusChangeEvent
+}
+
+// TransactionsAPI is the interface of the transactions service, implemented by *transactions.Service.
+// Code that needs a part of it should accept the narrower interface it embeds.
+type TransactionsAPI interface {
+	TransactionReader
+	TransactionLister
+	TransactionCreator
+	TransactionUpdater
+	TransactionWatcher
+}
+
+// CornerStoreReader reads single corner stores
+type CornerStoreReader interface {
+	Get(id string) (*models.CornerStore, error)
+	GetContext(ctx context.Context, id string) (*models.CornerStore, error)
+	GetCornerStoreInfoByExternalId(id int) (*models.CornerStoreInfo, error)
+	GetCornerStoreInfoByExternalIdContext(ctx context.Context, id int) (*models.CornerStoreInfo, error)
+}
+
+// CornerStoreLister lists corner stores, one page at a time or through an iterator
+type CornerStoreLister interface {
+	List(params *models.CornerStoreListParams) (*models.CornerStoreListResponse, error)
+	ListContext(ctx context.Context, params *models.CornerStoreListParams) (*models.CornerStoreListResponse, error)
+	ListAll(ctx context.Context, params *models.CornerStoreListParams, opts *client.IteratorOptions) iter.Seq2[models.CornerStore, error]
+	ListIterator(ctx context.Context, params *models.CornerStoreListParams, opts *client.IteratorOptions) *client.Iterator[models.CornerStore]
+}
+
+// CornerStoreWriter creates, updates and deletes corner stores
+type CornerStoreWriter interface {
+	Create(params *models.CornerStoreCreateParams) (*models.CornerStore, error)
+	CreateContext(ctx context.Context, params *models.CornerStoreCreateParams) (*models.CornerStore, error)
+	Update(id string, params *models.CornerStoreUpdateParams) (*models.CornerStore, error)
+	UpdateContext(ctx context.Context, id string, params *models.CornerStoreUpdateParams) (*models.CornerStore, error)
+	Delete(id string) error
+	DeleteContext(ctx context.Context, id string) error
+}
+
+// CornerStoresAPI is the interface of the corner store service, implemented by *cornerstore.Service.
+// Code that needs a part of it should accept the narrower interface it embeds.
+type CornerStoresAPI interface {
+	CornerStoreReader
+	CornerStoreLister
+	CornerStoreWriter
+}
+
+// KYCReader reads single KYC verifications
+type KYCReader interface {
+	Get(id string) (*models.KYC, error)
+	GetContext(ctx context.Context, id string) (*models.KYC, error)
+}
+
+// KYCLister lists KYC verifications, one page at a time or through an iterator
+type KYCLister interface {
+	List(params *models.KYCListParams) (*models.KYCListResponse, error)
+	ListContext(ctx context.Context, params *models.KYCListParams) (*models.KYCListResponse, error)
+	ListAll(ctx context.Context, params *models.KYCListParams, opts *client.IteratorOptions) iter.Seq2[models.KYC, error]
+	ListIterator(ctx context.Context, params *models.KYCListParams, opts *client.IteratorOptions) *client.Iterator[models.KYC]
+}
+
+// KYCWriter creates and updates KYC verifications
+type KYCWriter interface {
+	Create(params *models.KYCCreateParams) (*models.KYC, error)
+	CreateContext(ctx context.Context, params *models.KYCCreateParams) (*models.KYC, error)
+	Update(id string, params *models.KYCUpdateParams) (*models.KYC, error)
+	UpdateContext(ctx context.Context, id string, params *models.KYCUpdateParams) (*models.KYC, error)
+}
+
+// KYCReviewer verifies and rejects KYC verifications
+type KYCReviewer interface {
+	Verify(id string) (*models.KYC, error)
+	VerifyContext(ctx context.Context, id string) (*models.KYC, error)
+	Reject(id string, reason string) (*models.KYC, error)
+	RejectContext(ctx context.Context, id string, reason string) (*models.KYC, error)
+}
+
+// KYCAPI is the interface of the KYC service, implemented by *kyc.Service.
+// Code that needs a part of it should accept the narrower interface it embeds.
+type KYCAPI interface {
+	KYCReader
+	KYCLister
+	KYCWriter
+	KYCReviewer
+}
+
+// AccountReader reads single accounts
+type AccountReader interface {
+	Get(id string) (*models.Account, error)
+	GetContext(ctx context.Context, id string) (*models.Account, error)
+}
+
+// AccountLister lists accounts, one page at a time or through an iterator
+type AccountLister interface {
+	List(params *models.AccountListParams) (*models.AccountListResponse, error)
+	ListContext(ctx context.Context, params *models.AccountListParams) (*models.AccountListResponse, error)
+	ListAll(ctx context.Context, params *models.AccountListParams, opts *client.IteratorOptions) iter.Seq2[models.Account, error]
+	ListIterator(ctx context.Context, params *models.AccountListParams, opts *client.IteratorOptions) *client.Iterator[models.Account]
+}
+
+// AccountWriter creates and updates accounts
+type AccountWriter interface {
+	Create(params *models.AccountCreateParams) (*models.Account, error)
+	CreateContext(ctx context.Context, params *models.AccountCreateParams) (*models.Account, error)
+	Update(id string, params *models.AccountUpdateParams) (*models.Account, error)
+	UpdateContext(ctx context.Context, id string, params *models.AccountUpdateParams) (*models.Account, error)
+}
+
+// AccountStatusUpdater suspends and activates accounts
+type AccountStatusUpdater interface {
+	Suspend(id string) (*models.Account, error)
+	SuspendContext(ctx context.Context, id string) (*models.Account, error)
+	Activate(id string) (*models.Account, error)
+	ActivateContext(ctx context.Context, id string) (*models.Account, error)
+}
+
+// AccountsAPI is the interface of the account service, implemented by *account.Service.
+// Code that needs a part of it should accept the narrower interface it embeds.
+type AccountsAPI interface {
+	AccountReader
+	AccountLister
+	AccountWriter
+	AccountStatusUpdater
+}
+
+// Compile-time checks that the services implement their interfaces
+var (
+	_ AuthAPI         = (*auth.Service)(nil)
+	_ TransactionsAPI = (*transactions.Service)(nil)
+	_ CornerStoresAPI = (*cornerstore.Service)(nil)
+	_ KYCAPI          = (*kyc.Service)(nil)
+	_ AccountsAPI     = (*account.Service)(nil)
+)
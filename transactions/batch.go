@@ -0,0 +1,41 @@
+package transactions
+
+import (
+	"context"
+
+	"github.com/diogenes-moreira/propaga-sdk/internal/batch"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// DefaultBatchConcurrency is the number of transactions created at once when none is configured
+const DefaultBatchConcurrency = batch.DefaultConcurrency
+
+// BatchOptions configures CreateBatch:
+//
+//   - Concurrency is the number of transactions created at once, DefaultBatchConcurrency when zero
+type BatchOptions = batch.Options
+
+// BatchItemResult is the outcome of the creation of a transaction of a batch:
+//
+//   - Index is the position of the item in the batch
+//   - Params are the parameters the transaction was created with
+//   - Transaction is the created transaction, nil when Err is set
+//   - Err is the error returned by the creation, such as a *client.Error or a *models.ValidationError
+type BatchItemResult = batch.ItemResult
+
+// BatchResult is the outcome of CreateBatch:
+//
+//   - Items holds a BatchItemResult per item, in the order of the batch
+//   - Succeeded and Failed count the items created and the items that failed
+//
+// Its Failures and Transactions methods return the items that failed and the created transactions.
+type BatchResult = batch.Result
+
+// CreateBatch creates every transaction of params using a pool of workers.
+// Each creation goes through CreateContext, so it honors the rate limit of the client and
+// carries an idempotency key, making it safe to submit the same batch again after a failure.
+// The error is only set when ctx is done before every item was processed, the items left
+// then fail with ErrRequestCanceled.
+func (s *Service) CreateBatch(ctx context.Context, params []*models.TransactionCreateParams, opts *BatchOptions) (*BatchResult, error) {
+	return batch.Run(ctx, s.CreateContext, params, opts)
+}
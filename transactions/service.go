@@ -1,7 +1,9 @@
 package transactions
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/diogenes-moreira/propaga-sdk/client"
@@ -11,6 +13,13 @@ import (
 // Service provides methods for interacting with transactions in the Propaga API
 type Service struct {
 	client *client.Client
+
+	// CheckTransitions makes Update and Cancel fetch the transaction first and refuse
+	// illegal status transitions with a *models.TransitionError without sending them.
+	// It costs an extra request, and the status may still change between both requests,
+	// so the API remains the authority. Update checks the transition locally, without
+	// the extra request, when TransactionUpdateParams.CurrentStatus is set.
+	CheckTransitions bool
 }
 
 // NewService creates a new instance of the transactions service
@@ -22,10 +31,21 @@ func NewService(client *client.Client) *Service {
 
 // List retrieves a list of transactions based on the specified parameters
 func (s *Service) List(params *models.TransactionListParams) (*models.TransactionListResponse, error) {
+	return s.ListContext(context.Background(), params)
+}
+
+// ListContext is like List but honors ctx for cancellation and deadlines
+func (s *Service) ListContext(ctx context.Context, params *models.TransactionListParams) (*models.TransactionListResponse, error) {
 	result := &models.TransactionListResponse{}
 
 	// Endpoint placeholder - should be updated when documentation is available
-	err := s.client.DoRequest(http.MethodGet, "/v1/transaction", params, result)
+	query, err := client.EncodeQuery(params)
+	if err != nil {
+		return nil, fmt.Errorf("error listing transactions: %w", err)
+	}
+
+	err = s.client.DoRequestContext(ctx, http.MethodGet, "/v1/transaction", nil, result,
+		client.WithQuery(query), client.WithOperation("transactions", "List"))
 	if err != nil {
 		return nil, fmt.Errorf("error listing transactions: %w", err)
 	}
@@ -33,13 +53,39 @@ func (s *Service) List(params *models.TransactionListParams) (*models.Transactio
 	return result, nil
 }
 
+// ListAll returns a sequence over every transaction matching params, fetching pages lazily.
+// params.Offset is used as the starting point and params.Limit as page size unless opts sets one.
+func (s *Service) ListAll(ctx context.Context, params *models.TransactionListParams, opts *client.IteratorOptions) iter.Seq2[models.Transaction, error] {
+	return s.ListIterator(ctx, params, opts).All()
+}
+
+// ListIterator returns an iterator over every transaction matching params, fetching pages lazily
+func (s *Service) ListIterator(ctx context.Context, params *models.TransactionListParams, opts *client.IteratorOptions) *client.Iterator[models.Transaction] {
+	pageFields := func(p *models.TransactionListParams) (*int, *int) { return &p.Limit, &p.Offset }
+	list := func(ctx context.Context, page *models.TransactionListParams) ([]models.Transaction, int, error) {
+		result, err := s.ListContext(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Data, result.TotalCount, nil
+	}
+
+	return client.ListIterator(ctx, params, pageFields, list, opts)
+}
+
 // Get retrieves a specific transaction by its ID
 func (s *Service) Get(id string) (*models.Transaction, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but honors ctx for cancellation and deadlines
+func (s *Service) GetContext(ctx context.Context, id string) (*models.Transaction, error) {
 	result := &models.Transaction{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/transaction/%s", id)
-	err := s.client.DoRequest(http.MethodGet, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodGet, path, nil, result,
+		client.WithOperation("transactions", "Get"), client.WithAttribute(client.AttributeTransactionID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error getting transaction %s: %w", id, err)
 	}
@@ -49,11 +95,17 @@ func (s *Service) Get(id string) (*models.Transaction, error) {
 
 // GetByExternalID retrieves a transaction by its external ID
 func (s *Service) GetByExternalID(externalID string) (*models.Transaction, error) {
+	return s.GetByExternalIDContext(context.Background(), externalID)
+}
+
+// GetByExternalIDContext is like GetByExternalID but honors ctx for cancellation and deadlines
+func (s *Service) GetByExternalIDContext(ctx context.Context, externalID string) (*models.Transaction, error) {
 	result := &models.Transaction{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/transaction/external/%s", externalID)
-	err := s.client.DoRequest(http.MethodGet, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodGet, path, nil, result,
+		client.WithOperation("transactions", "GetByExternalID"), client.WithAttribute(client.AttributeTransactionExternalID, externalID))
 	if err != nil {
 		return nil, fmt.Errorf("error getting transaction by external ID %s: %w", externalID, err)
 	}
@@ -61,12 +113,24 @@ func (s *Service) GetByExternalID(externalID string) (*models.Transaction, error
 	return result, nil
 }
 
-// Create creates a new transaction
+// Create validates params and creates a new transaction
+// The request carries an idempotency key, so retrying it never creates a duplicate credit
 func (s *Service) Create(params *models.TransactionCreateParams) (*models.Transaction, error) {
+	return s.CreateContext(context.Background(), params)
+}
+
+// CreateContext is like Create but honors ctx for cancellation and deadlines
+func (s *Service) CreateContext(ctx context.Context, params *models.TransactionCreateParams) (*models.Transaction, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error creating transaction: %w", err)
+	}
+
 	result := &models.Transaction{}
 
 	// Endpoint placeholder - should be updated when documentation is available
-	err := s.client.DoRequest(http.MethodPost, "/v1/transaction", params, result)
+	key := idempotencyKey("transaction-create", params.IdempotencyKey, params.WholesalerTransactionId)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, "/v1/transaction", params, result,
+		client.WithIdempotencyKey(key), client.WithOperation("transactions", "Create"), client.WithAttribute(client.AttributeCornerStoreID, params.CornerStoreId))
 	if err != nil {
 		return nil, fmt.Errorf("error creating transaction: %w", err)
 	}
@@ -74,13 +138,31 @@ func (s *Service) Create(params *models.TransactionCreateParams) (*models.Transa
 	return result, nil
 }
 
-// Update updates an existing transaction
+// Update validates params and updates an existing transaction
+// When params changes the status from a known CurrentStatus, or CheckTransitions is set, an illegal
+// transition is refused with a *models.TransitionError before calling the API
 func (s *Service) Update(id string, params *models.TransactionUpdateParams) (*models.Transaction, error) {
+	return s.UpdateContext(context.Background(), id, params)
+}
+
+// UpdateContext is like Update but honors ctx for cancellation and deadlines
+func (s *Service) UpdateContext(ctx context.Context, id string, params *models.TransactionUpdateParams) (*models.Transaction, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error updating transaction %s: %w", id, err)
+	}
+
+	if params != nil && params.Status != "" {
+		if err := s.checkTransition(ctx, id, params.CurrentStatus, params.Status); err != nil {
+			return nil, fmt.Errorf("error updating transaction %s: %w", id, err)
+		}
+	}
+
 	result := &models.Transaction{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/transaction/%s", id)
-	err := s.client.DoRequest(http.MethodPut, path, params, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPut, path, params, result,
+		client.WithOperation("transactions", "Update"), client.WithAttribute(client.AttributeTransactionID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error updating transaction %s: %w", id, err)
 	}
@@ -89,12 +171,24 @@ func (s *Service) Update(id string, params *models.TransactionUpdateParams) (*mo
 }
 
 // Cancel cancels an existing transaction
+// Transactions can only be cancelled before delivery. When CheckTransitions is set, other
+// statuses are refused with a *models.TransitionError before calling the API
 func (s *Service) Cancel(id string) (*models.Transaction, error) {
+	return s.CancelContext(context.Background(), id)
+}
+
+// CancelContext is like Cancel but honors ctx for cancellation and deadlines
+func (s *Service) CancelContext(ctx context.Context, id string) (*models.Transaction, error) {
+	if err := s.checkTransition(ctx, id, "", models.TransactionStatusCancelled); err != nil {
+		return nil, fmt.Errorf("error canceling transaction %s: %w", id, err)
+	}
+
 	result := &models.Transaction{}
 
 	// Endpoint placeholder - should be updated when documentation is available
 	path := fmt.Sprintf("/v1/transaction/%s/cancel", id)
-	err := s.client.DoRequest(http.MethodPost, path, nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, path, nil, result,
+		client.WithOperation("transactions", "Cancel"), client.WithAttribute(client.AttributeTransactionID, id))
 	if err != nil {
 		return nil, fmt.Errorf("error canceling transaction %s: %w", id, err)
 	}
@@ -102,13 +196,26 @@ func (s *Service) Cancel(id string) (*models.Transaction, error) {
 	return result, nil
 }
 
-// CreateTransactionLink creates a transaction link for an external ID
+// CreateTransactionLink validates params and creates a transaction link for an external ID
 // This method is used to generate a link for external transactions, such as those from a wholesaler
 func (s *Service) CreateTransactionLink(id string, params *models.TransactionLinkParams) (*models.TransactionLinkResponse, error) {
+	return s.CreateTransactionLinkContext(context.Background(), id, params)
+}
+
+// CreateTransactionLinkContext is like CreateTransactionLink but honors ctx for cancellation and deadlines
+func (s *Service) CreateTransactionLinkContext(ctx context.Context, id string, params *models.TransactionLinkParams) (*models.TransactionLinkResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("error creating transaction link: %w", err)
+	}
+
 	result := &models.TransactionLinkResponse{}
 
 	path := fmt.Sprintf("/v1/link/external/%s", id)
-	err := s.client.DoRequest(http.MethodPost, path, params, result)
+	key := idempotencyKey("transaction-link", params.IdempotencyKey, params.Transaction.WholesalerTransactionId)
+	err := s.client.DoRequestContext(ctx, http.MethodPost, path, params, result,
+		client.WithIdempotencyKey(key), client.WithOperation("transactions", "CreateTransactionLink"),
+		client.WithAttribute(client.AttributeTransactionExternalID, id),
+		client.WithAttribute(client.AttributeCornerStoreID, params.Transaction.CornerStoreId))
 	if err != nil {
 		return nil, fmt.Errorf("error creating transaction link: %w", err)
 	}
@@ -116,12 +223,19 @@ func (s *Service) CreateTransactionLink(id string, params *models.TransactionLin
 	return result, nil
 }
 
+// GetPendingTransactions retrieves the transactions pending confirmation
 func (s *Service) GetPendingTransactions() (*models.PendingTransactionsResponse, error) {
+	return s.GetPendingTransactionsContext(context.Background())
+}
+
+// GetPendingTransactionsContext is like GetPendingTransactions but honors ctx for cancellation and deadlines
+func (s *Service) GetPendingTransactionsContext(ctx context.Context) (*models.PendingTransactionsResponse, error) {
 
 	result := &models.PendingTransactionsResponse{}
 
 	// Endpoint placeholder - should be updated when documentation is available
-	err := s.client.DoRequest(http.MethodGet, "/v1/transaction/pending", nil, result)
+	err := s.client.DoRequestContext(ctx, http.MethodGet, "/v1/transaction/pending", nil, result,
+		client.WithOperation("transactions", "GetPendingTransactions"))
 	if err != nil {
 		return nil, fmt.Errorf("error getting pending transactions %w", err)
 	}
@@ -129,3 +243,41 @@ func (s *Service) GetPendingTransactions() (*models.PendingTransactionsResponse,
 	return result, nil
 
 }
+
+// checkTransition checks that a transaction can move from status to next. When status is empty
+// it is fetched if CheckTransitions is set, and the check is left to the API otherwise.
+// Staying in a non-terminal status is allowed, e.g. to update other fields, while terminal
+// statuses are final. Statuses unknown to the SDK are left for the API to validate.
+func (s *Service) checkTransition(ctx context.Context, id string, status, next models.TransactionStatus) error {
+	if status == "" {
+		if !s.CheckTransitions {
+			return nil
+		}
+		current, err := s.GetContext(ctx, id)
+		if err != nil {
+			return err
+		}
+		status = current.TransactionStatus
+	}
+
+	if !status.IsValid() || (status == next && !status.IsTerminal()) {
+		return nil
+	}
+
+	return status.ValidateTransition(next)
+}
+
+// idempotencyKey returns the caller supplied key, or one derived from the wholesaler
+// transaction ID so that retries of the same order are deduplicated by the API.
+// A random key is generated when neither is available.
+// The derived key outlives the transaction: re-creating a cancelled order with the same
+// wholesaler transaction ID needs a caller supplied key.
+func idempotencyKey(prefix, key, wholesalerTransactionID string) string {
+	if key != "" {
+		return key
+	}
+	if wholesalerTransactionID != "" {
+		return fmt.Sprintf("%s-%s", prefix, wholesalerTransactionID)
+	}
+	return client.NewIdempotencyKey()
+}
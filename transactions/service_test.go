@@ -0,0 +1,169 @@
+package transactions_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	propaga "github.com/diogenes-moreira/propaga-sdk"
+	"github.com/diogenes-moreira/propaga-sdk/client"
+	"github.com/diogenes-moreira/propaga-sdk/models"
+	"github.com/diogenes-moreira/propaga-sdk/propagatest"
+)
+
+func TestTransitionsCheckedBeforeSending(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  models.TransactionStatus
+		update  models.TransactionStatus
+		cancel  bool
+		wantErr bool
+	}{
+		{name: "cancel pending", status: models.TransactionStatusPending, cancel: true},
+		{name: "cancel cancelled", status: models.TransactionStatusCancelled, cancel: true, wantErr: true},
+		{name: "cancel paid", status: models.TransactionStatusPaid, cancel: true, wantErr: true},
+		{name: "update paid to paid", status: models.TransactionStatusPaid, update: models.TransactionStatusPaid, wantErr: true},
+		{name: "update pending to pending", status: models.TransactionStatusPending, update: models.TransactionStatusPending},
+		{name: "update expired to pending", status: models.TransactionStatusExpired, update: models.TransactionStatusPending, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := propagatest.NewServer()
+			defer server.Close()
+			tx := server.AddTransaction(models.Transaction{TransactionStatus: tt.status})
+			service := server.Client(propaga.WithTransitionChecks()).Transactions
+
+			var err error
+			if tt.cancel {
+				_, err = service.Cancel(tx.TransactionId)
+			} else {
+				_, err = service.Update(tx.TransactionId, &models.TransactionUpdateParams{Status: tt.update})
+			}
+
+			if tt.wantErr != errors.Is(err, models.ErrInvalidTransition) {
+				t.Fatalf("error = %v, want ErrInvalidTransition: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				for _, req := range server.Requests() {
+					if req.Method != http.MethodGet {
+						t.Errorf("refused transition sent %s %s", req.Method, req.Path)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTransitionsCheckedWithCurrentStatus(t *testing.T) {
+	server := propagatest.NewServer()
+	defer server.Close()
+	tx := server.AddTransaction(models.Transaction{TransactionStatus: models.TransactionStatusOnHold})
+	service := server.Client().Transactions
+
+	_, err := service.Update(tx.TransactionId, &models.TransactionUpdateParams{
+		CurrentStatus: models.TransactionStatusPaid,
+		Status:        models.TransactionStatusDelivery,
+	})
+	if !errors.Is(err, models.ErrInvalidTransition) {
+		t.Fatalf("Update() error = %v, want ErrInvalidTransition", err)
+	}
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Fatalf("refused transition sent %d requests", len(requests))
+	}
+
+	updated, err := service.Update(tx.TransactionId, &models.TransactionUpdateParams{
+		CurrentStatus: models.TransactionStatusOnHold,
+		Status:        models.TransactionStatusDelivery,
+	})
+	if err != nil || updated.TransactionStatus != models.TransactionStatusDelivery {
+		t.Fatalf("Update() = %v, %v, want the delivery status", updated, err)
+	}
+	if requests := server.Requests(); len(requests) != 1 || requests[0].Method != http.MethodPut {
+		t.Errorf("got requests %+v, want a single PUT", requests)
+	}
+}
+
+func TestTransitionsLeftToTheAPIByDefault(t *testing.T) {
+	server := propagatest.NewServer()
+	defer server.Close()
+	tx := server.AddTransaction(models.Transaction{TransactionStatus: models.TransactionStatusPaid})
+	service := server.Client().Transactions
+
+	_, err := service.Cancel(tx.TransactionId)
+	if !client.IsConflict(err) {
+		t.Fatalf("Cancel() error = %v, want the conflict returned by the API", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Method != http.MethodPost {
+		t.Errorf("got requests %+v, want a single POST", requests)
+	}
+}
+
+func TestContextMethodsHonorCancellation(t *testing.T) {
+	server := propagatest.NewServer()
+	defer server.Close()
+	server.SetLatency(time.Second)
+	tx := server.AddTransaction(models.Transaction{})
+	service := server.Client().Transactions
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := service.GetContext(ctx, tx.TransactionId)
+	if !errors.Is(err, client.ErrRequestCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetContext() error = %v, want ErrRequestCanceled wrapping context.DeadlineExceeded", err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = service.ListContext(canceled, nil)
+	if !errors.Is(err, client.ErrRequestCanceled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("ListContext() error = %v, want ErrRequestCanceled wrapping context.Canceled", err)
+	}
+}
+
+func TestCreateSendsIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantKey string
+	}{
+		{name: "derived from wholesaler transaction ID", wantKey: "transaction-create-order-1"},
+		{name: "caller supplied", key: "retry-1", wantKey: "retry-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := propagatest.NewServer()
+			defer server.Close()
+			server.Fail(propagatest.Failure{Method: http.MethodPost, Path: "/v1/transaction", Status: http.StatusServiceUnavailable, Times: 2})
+			service := server.Client(propaga.WithRetryPolicy(&client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})).Transactions
+
+			_, err := service.Create(&models.TransactionCreateParams{
+				CornerStoreId:           "cs-1",
+				TotalAmount:             models.MXN(10000),
+				WholesalerTransactionId: "order-1",
+				DeliveryDate:            models.NewDate(2025, time.January, 2),
+				Products:                []models.Product{{ExternalSKU: "sku-1", Name: "Product", Quantity: 1}},
+				IdempotencyKey:          tt.key,
+			})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			requests := server.Requests()
+			if len(requests) != 3 {
+				t.Fatalf("got %d requests, want 3", len(requests))
+			}
+			for _, req := range requests {
+				if got := req.Header.Get(client.IdempotencyKeyHeader); got != tt.wantKey {
+					t.Errorf("%s header = %q, want %q", client.IdempotencyKeyHeader, got, tt.wantKey)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package transactions
+
+import (
+	"context"
+
+	"github.com/diogenes-moreira/propaga-sdk/internal/watch"
+)
+
+// Default values used by Watch and WatchMany
+const (
+	DefaultWatchInterval    = watch.DefaultInterval
+	DefaultWatchMaxInterval = watch.DefaultMaxInterval
+)
+
+// WatchOptions configures Watch and WatchMany:
+//
+//   - Interval is the delay between polls, DefaultWatchInterval when zero. It doubles while
+//     the status does not change and resets on every change.
+//   - MaxInterval caps the delay between polls, DefaultWatchMaxInterval when zero
+type WatchOptions = watch.Options
+
+// StatusChangeEvent reports a change of the status of a watched transaction:
+//
+//   - TransactionID is the ID of the watched transaction
+//   - OldStatus is the status previously seen, empty for the first event of a transaction
+//   - NewStatus is the current status of the transaction
+//   - Transaction is the transaction as last retrieved, nil when Err is set
+//   - Err is set when the transaction cannot be watched anymore, e.g. because it does not exist
+type StatusChangeEvent = watch.StatusChangeEvent
+
+// Watch polls the transaction id and emits an event each time its status changes, the first
+// event carrying the status found by the first poll. The channel is closed once the transaction
+// reaches a terminal status, when it cannot be retrieved anymore or when ctx is done.
+// Network errors and API errors of status 429 or 5xx are retried with the polling backoff,
+// any other error, such as a 404 or a response failing strict decoding, ends the watch with
+// an event carrying it.
+func (s *Service) Watch(ctx context.Context, id string, opts *WatchOptions) <-chan StatusChangeEvent {
+	return watch.Run(ctx, s.GetContext, []string{id}, opts)
+}
+
+// WatchMany is like Watch for several transactions, merging their events in a single channel
+// closed once every transaction stopped being watched
+func (s *Service) WatchMany(ctx context.Context, ids []string, opts *WatchOptions) <-chan StatusChangeEvent {
+	return watch.Run(ctx, s.GetContext, ids, opts)
+}
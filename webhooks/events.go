@@ -0,0 +1,78 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/diogenes-moreira/propaga-sdk/models"
+)
+
+// EventType identifies the kind of a webhook event
+type EventType string
+
+// Event types sent by Propaga
+const (
+	EventTransactionStatusChanged EventType = "transaction.status_changed"
+	EventKYCVerified              EventType = "kyc.verified"
+	EventKYCRejected              EventType = "kyc.rejected"
+	EventAccountSuspended         EventType = "account.suspended"
+)
+
+// Event is the envelope shared by every webhook event
+type Event struct {
+	ID        string           `json:"id"`
+	Type      EventType        `json:"type"`
+	CreatedAt models.Timestamp `json:"created_at"`
+	Data      json.RawMessage  `json:"data"`
+}
+
+// TransactionStatusChangedEvent is sent when a transaction moves through its lifecycle
+// (pending-verification, on-hold, delivery, paid, cancel or expired)
+type TransactionStatusChangedEvent struct {
+	Event          `json:"-"`
+	TransactionId  string                   `json:"transactionId"`
+	PreviousStatus models.TransactionStatus `json:"previousStatus"`
+	Status         models.TransactionStatus `json:"status"`
+	Transaction    models.Transaction       `json:"transaction"`
+}
+
+// KYCVerifiedEvent is sent when a KYC verification is approved
+type KYCVerifiedEvent struct {
+	Event `json:"-"`
+	KYC   models.KYC `json:"kyc"`
+}
+
+// KYCRejectedEvent is sent when a KYC verification is rejected
+type KYCRejectedEvent struct {
+	Event  `json:"-"`
+	KYC    models.KYC `json:"kyc"`
+	Reason string     `json:"reason"`
+}
+
+// AccountSuspendedEvent is sent when an account is suspended
+type AccountSuspendedEvent struct {
+	Event   `json:"-"`
+	Account models.Account `json:"account"`
+	Reason  string         `json:"reason,omitempty"`
+}
+
+// ParseEvent decodes the envelope of a webhook payload without verifying it
+func ParseEvent(payload []byte) (*Event, error) {
+	event := &Event{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("error decoding webhook event: %w", err)
+	}
+	if event.Type == "" {
+		return nil, fmt.Errorf("error decoding webhook event: missing type")
+	}
+
+	return event, nil
+}
+
+// decodeData decodes the data of event into v
+func decodeData(event *Event, v interface{}) error {
+	if err := json.Unmarshal(event.Data, v); err != nil {
+		return fmt.Errorf("error decoding %s event data: %w", event.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultMaxBodySize is the maximum size of a webhook payload accepted by default
+const DefaultMaxBodySize = 1 << 20
+
+// ReplayStore remembers the webhooks already received so that they are processed only once
+type ReplayStore interface {
+	// Seen records key until expiresAt and reports whether it was already recorded
+	Seen(key string, expiresAt time.Time) bool
+
+	// Forget removes key so that a webhook that failed to be handled can be delivered again
+	Forget(key string)
+}
+
+// Handler is an http.Handler receiving Propaga webhooks. It verifies their signature and
+// timestamp, rejects replays and dispatches the decoded events to the registered callbacks.
+//
+// Callbacks returning an error make the handler answer with status 500 so that the webhook
+// is delivered again. Events without a registered callback are acknowledged and ignored.
+type Handler struct {
+	secret      string
+	tolerance   time.Duration
+	maxBodySize int64
+	replays     ReplayStore
+	now         func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[EventType]func(context.Context, *Event) error
+}
+
+// HandlerOption configures a Handler
+type HandlerOption func(*Handler)
+
+// WithTolerance sets the maximum age of an accepted webhook, DefaultTolerance by default.
+// The timestamp check cannot be disabled: values that are not positive are ignored.
+func WithTolerance(tolerance time.Duration) HandlerOption {
+	return func(h *Handler) {
+		if tolerance > 0 {
+			h.tolerance = tolerance
+		}
+	}
+}
+
+// WithMaxBodySize sets the maximum size of an accepted payload, DefaultMaxBodySize by default
+func WithMaxBodySize(size int64) HandlerOption {
+	return func(h *Handler) {
+		h.maxBodySize = size
+	}
+}
+
+// WithReplayStore replaces the in-memory replay store, e.g. with one shared between instances
+func WithReplayStore(store ReplayStore) HandlerOption {
+	return func(h *Handler) {
+		h.replays = store
+	}
+}
+
+// WithClock replaces the clock used to check timestamps
+func WithClock(now func() time.Time) HandlerOption {
+	return func(h *Handler) {
+		h.now = now
+	}
+}
+
+// NewHandler creates a webhook handler verifying signatures with secret.
+// Every webhook is refused with ErrEmptySecret when secret is empty.
+func NewHandler(secret string, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		secret:      secret,
+		tolerance:   DefaultTolerance,
+		maxBodySize: DefaultMaxBodySize,
+		replays:     NewMemoryReplayStore(),
+		now:         time.Now,
+		handlers:    make(map[EventType]func(context.Context, *Event) error),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// On registers fn for the events of the given type, replacing any previous callback
+func (h *Handler) On(eventType EventType, fn func(context.Context, *Event) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[eventType] = fn
+}
+
+// OnTransactionStatusChanged registers fn for transaction.status_changed events
+func (h *Handler) OnTransactionStatusChanged(fn func(context.Context, *TransactionStatusChangedEvent) error) {
+	h.On(EventTransactionStatusChanged, func(ctx context.Context, event *Event) error {
+		typed := &TransactionStatusChangedEvent{Event: *event}
+		if err := decodeData(event, typed); err != nil {
+			return err
+		}
+		return fn(ctx, typed)
+	})
+}
+
+// OnKYCVerified registers fn for kyc.verified events
+func (h *Handler) OnKYCVerified(fn func(context.Context, *KYCVerifiedEvent) error) {
+	h.On(EventKYCVerified, func(ctx context.Context, event *Event) error {
+		typed := &KYCVerifiedEvent{Event: *event}
+		if err := decodeData(event, typed); err != nil {
+			return err
+		}
+		return fn(ctx, typed)
+	})
+}
+
+// OnKYCRejected registers fn for kyc.rejected events
+func (h *Handler) OnKYCRejected(fn func(context.Context, *KYCRejectedEvent) error) {
+	h.On(EventKYCRejected, func(ctx context.Context, event *Event) error {
+		typed := &KYCRejectedEvent{Event: *event}
+		if err := decodeData(event, typed); err != nil {
+			return err
+		}
+		return fn(ctx, typed)
+	})
+}
+
+// OnAccountSuspended registers fn for account.suspended events
+func (h *Handler) OnAccountSuspended(fn func(context.Context, *AccountSuspendedEvent) error) {
+	h.On(EventAccountSuspended, func(ctx context.Context, event *Event) error {
+		typed := &AccountSuspendedEvent{Event: *event}
+		if err := decodeData(event, typed); err != nil {
+			return err
+		}
+		return fn(ctx, typed)
+	})
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, h.maxBodySize+1))
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(payload)) > h.maxBodySize {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	event, err := h.ConstructEvent(payload, r.Header.Get(SignatureHeader))
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, ErrEmptySecret):
+			status = http.StatusInternalServerError
+		case errors.Is(err, ErrMissingSignature), errors.Is(err, ErrInvalidSignature), errors.Is(err, ErrTimestampOutOfRange):
+			status = http.StatusUnauthorized
+		case errors.Is(err, ErrReplayed):
+			// Already processed, acknowledge so that it is not delivered again
+			status = http.StatusOK
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if err := h.Dispatch(r.Context(), event); err != nil {
+		if h.replays != nil {
+			h.replays.Forget(replayKey(event, r.Header.Get(SignatureHeader)))
+		}
+		http.Error(w, "error handling webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ConstructEvent verifies the signature of payload, rejects replays and decodes its envelope
+func (h *Handler) ConstructEvent(payload []byte, signature string) (*Event, error) {
+	timestamp, err := VerifySignature(payload, signature, h.secret, h.tolerance, h.now())
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := ParseEvent(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// The key is kept for as long as the timestamp is accepted by VerifySignature
+	key := replayKey(event, signature)
+	if h.replays != nil && h.replays.Seen(key, timestamp.Add(h.tolerance)) {
+		return nil, fmt.Errorf("%w: %s", ErrReplayed, key)
+	}
+
+	return event, nil
+}
+
+// replayKey identifies a webhook delivery, by event ID when available
+func replayKey(event *Event, signature string) string {
+	if event.ID != "" {
+		return event.ID
+	}
+	return signature
+}
+
+// Dispatch calls the callback registered for the type of event, if any
+func (h *Handler) Dispatch(ctx context.Context, event *Event) error {
+	h.mu.RLock()
+	fn := h.handlers[event.Type]
+	h.mu.RUnlock()
+
+	if fn == nil {
+		return nil
+	}
+
+	return fn(ctx, event)
+}
+
+// MemoryReplayStore is a ReplayStore keeping the received keys in memory
+type MemoryReplayStore struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+	now  func() time.Time
+}
+
+// NewMemoryReplayStore creates an empty in-memory replay store
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{
+		keys: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+// Seen implements ReplayStore. Expired keys are pruned on each call.
+func (s *MemoryReplayStore) Seen(key string, expiresAt time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, exp := range s.keys {
+		if now.After(exp) {
+			delete(s.keys, k)
+		}
+	}
+
+	if _, ok := s.keys[key]; ok {
+		return true
+	}
+	s.keys[key] = expiresAt
+
+	return false
+}
+
+// Forget implements ReplayStore
+func (s *MemoryReplayStore) Forget(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+}
@@ -0,0 +1,184 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testClock is a settable clock shared by a Handler and its MemoryReplayStore
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+// newTestHandler creates a handler and a replay store driven by clock
+func newTestHandler(clock *testClock, opts ...HandlerOption) *Handler {
+	store := NewMemoryReplayStore()
+	store.now = clock.Now
+	opts = append([]HandlerOption{WithClock(clock.Now), WithReplayStore(store)}, opts...)
+	return NewHandler("secret", opts...)
+}
+
+// deliver posts payload signed at signedAt to h and returns the response status
+func deliver(h http.Handler, payload, secret string, signedAt time.Time) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+	req.Header.Set(SignatureHeader, Sign([]byte(payload), secret, signedAt))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+const testPayload = `{"id":"evt_1","type":"kyc.rejected","data":{"kyc":{"id":"kyc_1"},"reason":"blurry"}}`
+
+func TestHandlerDispatches(t *testing.T) {
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+	h := newTestHandler(clock)
+
+	var got *KYCRejectedEvent
+	h.OnKYCRejected(func(ctx context.Context, event *KYCRejectedEvent) error {
+		got = event
+		return nil
+	})
+
+	if status := deliver(h, testPayload, "secret", clock.now); status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if got == nil || got.ID != "evt_1" || got.KYC.ID != "kyc_1" || got.Reason != "blurry" {
+		t.Errorf("dispatched event = %+v", got)
+	}
+}
+
+func TestHandlerRejectsInvalidSignatures(t *testing.T) {
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+
+	tests := []struct {
+		name     string
+		handler  *Handler
+		secret   string
+		signedAt time.Time
+		want     int
+	}{
+		{name: "wrong secret", handler: newTestHandler(clock), secret: "other", signedAt: clock.now, want: http.StatusUnauthorized},
+		{name: "too old", handler: newTestHandler(clock), secret: "secret", signedAt: clock.now.Add(-DefaultTolerance - time.Second), want: http.StatusUnauthorized},
+		{name: "zero tolerance keeps the default", handler: newTestHandler(clock, WithTolerance(0)), secret: "secret", signedAt: clock.now.Add(-24 * time.Hour), want: http.StatusUnauthorized},
+		{name: "empty secret", handler: NewHandler("", WithClock(clock.Now)), secret: "", signedAt: clock.now, want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			tt.handler.On(EventKYCRejected, func(ctx context.Context, event *Event) error {
+				called = true
+				return nil
+			})
+
+			if status := deliver(tt.handler, testPayload, tt.secret, tt.signedAt); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+			if called {
+				t.Errorf("callback called for a refused webhook")
+			}
+		})
+	}
+}
+
+func TestHandlerEmptySecretConstructEvent(t *testing.T) {
+	now := time.Now()
+	h := NewHandler("")
+
+	_, err := h.ConstructEvent([]byte(testPayload), Sign([]byte(testPayload), "", now))
+	if !errors.Is(err, ErrEmptySecret) {
+		t.Errorf("ConstructEvent() error = %v, want ErrEmptySecret", err)
+	}
+}
+
+func TestHandlerRejectsReplays(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	for _, tolerance := range []time.Duration{0, time.Minute, time.Hour} {
+		t.Run(tolerance.String(), func(t *testing.T) {
+			clock := &testClock{now: start}
+			h := newTestHandler(clock, WithTolerance(tolerance))
+			effective := tolerance
+			if effective <= 0 {
+				effective = DefaultTolerance
+			}
+
+			calls := 0
+			h.On(EventKYCRejected, func(ctx context.Context, event *Event) error {
+				calls++
+				return nil
+			})
+
+			if status := deliver(h, testPayload, "secret", start); status != http.StatusOK {
+				t.Fatalf("first delivery status = %d, want 200", status)
+			}
+
+			// A replay is acknowledged without calling the callback for as long as it
+			// would pass the timestamp check
+			for _, after := range []time.Duration{0, effective / 2, effective} {
+				clock.now = start.Add(after)
+				if status := deliver(h, testPayload, "secret", start); status != http.StatusOK {
+					t.Fatalf("replay after %v status = %d, want 200", after, status)
+				}
+			}
+
+			// Once the key expired, the timestamp check refuses the replay
+			clock.now = start.Add(effective + time.Second)
+			if status := deliver(h, testPayload, "secret", start); status != http.StatusUnauthorized {
+				t.Errorf("replay after tolerance status = %d, want 401", status)
+			}
+
+			if calls != 1 {
+				t.Errorf("callback called %d times, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestHandlerRedeliversFailedWebhooks(t *testing.T) {
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+	h := newTestHandler(clock)
+
+	calls := 0
+	h.On(EventKYCRejected, func(ctx context.Context, event *Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	if status := deliver(h, testPayload, "secret", clock.now); status != http.StatusInternalServerError {
+		t.Fatalf("failed delivery status = %d, want 500", status)
+	}
+	if status := deliver(h, testPayload, "secret", clock.now); status != http.StatusOK {
+		t.Fatalf("redelivery status = %d, want 200", status)
+	}
+	if calls != 2 {
+		t.Errorf("callback called %d times, want 2", calls)
+	}
+}
+
+func TestHandlerRequestLimits(t *testing.T) {
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+	h := newTestHandler(clock, WithMaxBodySize(10))
+
+	if status := deliver(h, testPayload, "secret", clock.now); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized payload status = %d, want 413", status)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", rec.Code)
+	}
+}
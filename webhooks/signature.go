@@ -0,0 +1,121 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the header carrying the signature of a webhook request.
+// Its value has the form "t=<unix timestamp>,v1=<hex encoded HMAC-SHA256>", where the
+// HMAC is computed with the shared secret over "<timestamp>.<raw body>".
+const SignatureHeader = "Propaga-Signature"
+
+// DefaultTolerance is the maximum age of a webhook accepted by default, and the one used
+// when the tolerance given is not positive
+const DefaultTolerance = 5 * time.Minute
+
+// Errors returned when a webhook signature cannot be verified
+var (
+	// ErrEmptySecret is returned when the secret used to verify signatures is empty,
+	// as anyone could then sign webhooks
+	ErrEmptySecret = errors.New("empty webhook secret")
+
+	// ErrMissingSignature is returned when the signature header is absent or malformed
+	ErrMissingSignature = errors.New("missing or malformed webhook signature")
+
+	// ErrInvalidSignature is returned when no signature matches the payload
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrTimestampOutOfRange is returned when the signed timestamp is outside the tolerance
+	ErrTimestampOutOfRange = errors.New("webhook timestamp outside of tolerance")
+
+	// ErrReplayed is returned when a webhook has already been received
+	ErrReplayed = errors.New("webhook already received")
+)
+
+// Sign computes the value of the signature header for payload sent at timestamp
+func Sign(payload []byte, secret string, timestamp time.Time) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeSignature(ts, payload, secret))
+}
+
+// VerifySignature checks that header is a valid signature of payload for secret and that it
+// was produced within tolerance of now, DefaultTolerance when not positive. It returns the
+// signed timestamp.
+func VerifySignature(payload []byte, header, secret string, tolerance time.Duration, now time.Time) (time.Time, error) {
+	if secret == "" {
+		return time.Time{}, ErrEmptySecret
+	}
+
+	ts, signatures, err := parseSignatureHeader(header)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, ErrMissingSignature
+	}
+	timestamp := time.Unix(unix, 0)
+
+	expected := computeSignature(ts, payload, secret)
+	valid := false
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return time.Time{}, ErrInvalidSignature
+	}
+
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	age := now.Sub(timestamp)
+	if age > tolerance || age < -tolerance {
+		return time.Time{}, ErrTimestampOutOfRange
+	}
+
+	return timestamp, nil
+}
+
+// parseSignatureHeader extracts the timestamp and the v1 signatures of a signature header
+func parseSignatureHeader(header string) (string, []string, error) {
+	var ts string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if ts == "" || len(signatures) == 0 {
+		return "", nil, ErrMissingSignature
+	}
+
+	return ts, signatures, nil
+}
+
+// computeSignature returns the hex encoded HMAC-SHA256 of "<timestamp>.<payload>"
+func computeSignature(ts string, payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
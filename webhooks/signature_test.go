@@ -0,0 +1,71 @@
+package webhooks
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"kyc.verified"}`)
+	now := time.Unix(1700000000, 0)
+	valid := Sign(payload, "secret", now)
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		header    string
+		secret    string
+		tolerance time.Duration
+		now       time.Time
+		wantErr   error
+	}{
+		{name: "valid", payload: payload, header: valid, secret: "secret", now: now},
+		{name: "within tolerance", payload: payload, header: valid, secret: "secret", now: now.Add(DefaultTolerance)},
+		{name: "slightly in the future", payload: payload, header: valid, secret: "secret", now: now.Add(-time.Minute)},
+		{name: "several signatures", payload: payload, header: "t=1700000000,v1=deadbeef," + valid[len("t=1700000000,"):], secret: "secret", now: now},
+		{name: "too old", payload: payload, header: valid, secret: "secret", now: now.Add(DefaultTolerance + time.Second), wantErr: ErrTimestampOutOfRange},
+		{name: "too far in the future", payload: payload, header: valid, secret: "secret", now: now.Add(-DefaultTolerance - time.Second), wantErr: ErrTimestampOutOfRange},
+		{name: "custom tolerance", payload: payload, header: valid, secret: "secret", tolerance: time.Hour, now: now.Add(30 * time.Minute)},
+		{name: "custom tolerance exceeded", payload: payload, header: valid, secret: "secret", tolerance: time.Second, now: now.Add(2 * time.Second), wantErr: ErrTimestampOutOfRange},
+		{name: "zero tolerance uses default", payload: payload, header: valid, secret: "secret", tolerance: 0, now: now.Add(24 * time.Hour), wantErr: ErrTimestampOutOfRange},
+		{name: "negative tolerance uses default", payload: payload, header: valid, secret: "secret", tolerance: -time.Second, now: now.Add(24 * time.Hour), wantErr: ErrTimestampOutOfRange},
+		{name: "wrong secret", payload: payload, header: valid, secret: "other", now: now, wantErr: ErrInvalidSignature},
+		{name: "tampered payload", payload: []byte(`{"id":"evt_2","type":"kyc.verified"}`), header: valid, secret: "secret", now: now, wantErr: ErrInvalidSignature},
+		{name: "tampered timestamp", payload: payload, header: "t=1700000001" + valid[len("t=1700000000"):], secret: "secret", now: now, wantErr: ErrInvalidSignature},
+		{name: "empty secret", payload: payload, header: Sign(payload, "", now), secret: "", now: now, wantErr: ErrEmptySecret},
+		{name: "missing header", payload: payload, header: "", secret: "secret", now: now, wantErr: ErrMissingSignature},
+		{name: "missing signature", payload: payload, header: "t=1700000000", secret: "secret", now: now, wantErr: ErrMissingSignature},
+		{name: "missing timestamp", payload: payload, header: valid[len("t=1700000000,"):], secret: "secret", now: now, wantErr: ErrMissingSignature},
+		{name: "invalid timestamp", payload: payload, header: "t=soon,v1=deadbeef", secret: "secret", now: now, wantErr: ErrMissingSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := VerifySignature(tt.payload, tt.header, tt.secret, tt.tolerance, tt.now)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("VerifySignature() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !ts.Equal(now) {
+				t.Errorf("VerifySignature() timestamp = %v, want %v", ts, now)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	header := Sign([]byte("payload"), "secret", now)
+
+	ts, signatures, err := parseSignatureHeader(header)
+	if err != nil {
+		t.Fatalf("parseSignatureHeader(%q) error = %v", header, err)
+	}
+	if ts != strconv.FormatInt(now.Unix(), 10) || len(signatures) != 1 || len(signatures[0]) != 64 {
+		t.Errorf("Sign() = %q, want t=<unix>,v1=<hex sha256>", header)
+	}
+	if Sign([]byte("payload"), "other", now) == header {
+		t.Errorf("Sign() does not depend on the secret")
+	}
+}